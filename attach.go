@@ -0,0 +1,33 @@
+package nice
+
+import (
+	"context"
+	"sync"
+)
+
+// AttachToContext registers the pairs globally while the context lives,
+// as Register in the given order, and deregisters them once the context is done.
+// The returned detach func deregisters them early.
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	defer cancel()
+//	nice.AttachToContext(ctx, nice.On(ErrJobAborted, logAbortedJob))
+func AttachToContext(ctx context.Context, pairs ...Pair) (detach func()) {
+	ids := make([]uint64, len(pairs))
+	for i, p := range pairs {
+		ids[i] = register(registration{
+			handler: toHandler(p.Target),
+			handle:  handleArtefact(p.Handle),
+		})
+	}
+
+	var once sync.Once
+	deregisterOnce := func() {
+		once.Do(func() { deregister(ids...) })
+	}
+	stop := context.AfterFunc(ctx, deregisterOnce)
+	return func() {
+		stop()
+		deregisterOnce()
+	}
+}
@@ -0,0 +1,41 @@
+package nice
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachToContext(t *testing.T) {
+	t.Run("deregister when done", func(t *testing.T) {
+		cleanRegistry(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		var executed []any
+		Register(reflect.TypeFor[int](), func(any) {})
+
+		AttachToContext(ctx,
+			On(reflect.TypeFor[string](), func(artefact any) { executed = append(executed, artefact) }),
+		)
+		guarded("attached")
+		cancel()
+
+		assert.Eventually(t, func() bool {
+			return len(loadRegistry().registrations) == 1
+		}, time.Second, time.Millisecond)
+		assert.Panics(t, func() { guarded("detached") })
+		assert.Equal(t, []any{"attached"}, executed)
+	})
+
+	t.Run("detach early", func(t *testing.T) {
+		cleanRegistry(t)
+
+		detach := AttachToContext(context.Background(), On(reflect.TypeFor[string](), func(any) {}))
+		assert.NotPanics(t, func() { guarded("attached") })
+		detach()
+
+		assert.Panics(t, func() { guarded("detached") })
+	})
+}
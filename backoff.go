@@ -0,0 +1,122 @@
+package nice
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// BackoffPolicy decides the delay before each retry, shared by Retry, Supervise and WebhookHandler,
+// so all backoff in the package behaves the same.
+type BackoffPolicy interface {
+	// Backoff returns the delay before the retry, counted from 1.
+	Backoff(retry int) time.Duration
+}
+
+// DefaultBackoff is the backoff of the webhook delivery retried by WebhookHandler.
+var DefaultBackoff BackoffPolicy = ExponentialBackoff{Initial: 100 * time.Millisecond, Max: 5 * time.Second}
+
+// ConstantBackoff waits the same delay before every retry.
+type ConstantBackoff time.Duration
+
+// Backoff returns the delay.
+func (b ConstantBackoff) Backoff(int) time.Duration {
+	return time.Duration(b)
+}
+
+// ExponentialBackoff multiplies the delay by Multiplier after every retry, starting at Initial, up to Max.
+type ExponentialBackoff struct {
+	Initial time.Duration
+	// Max bounds the delay, unless zero.
+	Max time.Duration
+	// Multiplier defaults to 2.
+	Multiplier float64
+}
+
+// Backoff returns Initial times Multiplier to the power of retry-1, up to Max.
+func (b ExponentialBackoff) Backoff(retry int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	delay := float64(b.Initial) * math.Pow(multiplier, float64(max(retry, 1)-1))
+	if b.Max > 0 && delay > float64(b.Max) {
+		return b.Max
+	}
+	if delay >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(delay)
+}
+
+// Jittered spreads the delays of the policy randomly by up to the fraction either way, e.g. 0.2 for ±20%,
+// so the retries of many processes do not synchronize.
+func Jittered(policy BackoffPolicy, fraction float64) BackoffPolicy {
+	return jittered{policy: policy, fraction: fraction}
+}
+
+type jittered struct {
+	policy   BackoffPolicy
+	fraction float64
+}
+
+func (j jittered) Backoff(retry int) time.Duration {
+	delay := float64(j.policy.Backoff(retry))
+	return time.Duration(delay * (1 + j.fraction*(2*rand.Float64()-1)))
+}
+
+// Sleep waits the delay of the policy before the retry, or until the context is done, returning its error.
+// It returns context.DeadlineExceeded right away if the delay would outlast the deadline of the context,
+// rather than waiting for a retry which cannot complete.
+// The deadline is compared by the system clock, by which contexts expire, even if SetClock replaced it.
+func Sleep(ctx context.Context, policy BackoffPolicy, retry int) error {
+	delay := policy.Backoff(retry)
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return fmt.Errorf("nice: backoff of %v exceeds the deadline: %w", delay, context.DeadlineExceeded)
+	}
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := currentClock().NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
+}
+
+// RetryOption configures the retries of Retry, Supervise and WebhookHandler.
+type RetryOption func(*retryConfig)
+
+type retryConfig struct {
+	ctx      context.Context
+	backoff  BackoffPolicy
+	attempts int
+}
+
+// WithBackoff sets the backoff between the attempts.
+func WithBackoff(policy BackoffPolicy) RetryOption {
+	return func(c *retryConfig) { c.backoff = policy }
+}
+
+// RetryContext sets the context of Retry, which gives up once it is done, or once its deadline is too near.
+func RetryContext(ctx context.Context) RetryOption {
+	return func(c *retryConfig) { c.ctx = ctx }
+}
+
+// RetryAttempts sets the attempts of WebhookHandler to deliver an event. Defaults to 1.
+func RetryAttempts(attempts int) RetryOption {
+	return func(c *retryConfig) { c.attempts = attempts }
+}
+
+func newRetryConfig(backoff BackoffPolicy, opts []RetryOption) retryConfig {
+	c := retryConfig{ctx: context.Background(), backoff: backoff, attempts: 1}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
@@ -0,0 +1,80 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoffPolicy(t *testing.T) {
+	assert.Equal(t, time.Second, ConstantBackoff(time.Second).Backoff(7))
+
+	exponential := ExponentialBackoff{Initial: 100 * time.Millisecond, Max: time.Second}
+	var delays []time.Duration
+	for retry := 1; retry <= 6; retry++ {
+		delays = append(delays, exponential.Backoff(retry))
+	}
+	assert.Equal(t, []time.Duration{
+		100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second,
+	}, delays)
+	assert.Equal(t, 900*time.Millisecond, ExponentialBackoff{Initial: 100 * time.Millisecond, Multiplier: 3}.Backoff(3))
+
+	jittered := Jittered(ConstantBackoff(time.Second), 0.2)
+	for range 100 {
+		delay := jittered.Backoff(1)
+		assert.GreaterOrEqual(t, delay, 800*time.Millisecond)
+		assert.LessOrEqual(t, delay, 1200*time.Millisecond)
+	}
+}
+
+func TestSleep(t *testing.T) {
+	assert.NoError(t, Sleep(context.Background(), ConstantBackoff(time.Millisecond), 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	start := time.Now()
+	err := Sleep(ctx, ConstantBackoff(2*time.Hour), 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "the delay outlasts the deadline")
+	assert.Less(t, time.Since(start), time.Second, "without waiting")
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, Sleep(cancelled, ConstantBackoff(time.Hour), 1), context.Canceled)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	var delays []time.Duration
+	last := time.Now()
+	attempts := 0
+	err := Retry(3, func() {
+		now := time.Now()
+		if attempts > 0 {
+			delays = append(delays, now.Sub(last))
+		}
+		last = now
+		if attempts++; attempts < 3 {
+			panic("flaky")
+		}
+	}, WithBackoff(ExponentialBackoff{Initial: 10 * time.Millisecond}))
+
+	assert.NoError(t, err)
+	assert.Len(t, delays, 2)
+	assert.GreaterOrEqual(t, delays[0], 10*time.Millisecond)
+	assert.GreaterOrEqual(t, delays[1], 20*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	attempts = 0
+	err = Retry(3, func() {
+		attempts++
+		panic("down")
+	}, RetryContext(ctx), WithBackoff(ConstantBackoff(time.Hour)))
+
+	assert.Equal(t, 1, attempts, "Retry gives up when the backoff outlasts the deadline")
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr, "with the panic of the last attempt")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
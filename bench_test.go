@@ -0,0 +1,111 @@
+package nice_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/antonyho/nice"
+)
+
+var errBenchmark = errors.New("benchmark")
+
+func noop(any) {}
+
+func BenchmarkTackleNoPanic(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		func() {
+			defer nice.Tackle(errBenchmark).With(noop)
+		}()
+	}
+}
+
+func BenchmarkTackleGenericNoPanic(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		func() {
+			defer nice.Tackle().With(noop)
+		}()
+	}
+}
+
+func BenchmarkRouteNoPanic(b *testing.B) {
+	policy := nice.Route(
+		nice.On(errBenchmark, noop),
+		nice.On(reflect.TypeFor[string](), noop),
+	)
+	b.ReportAllocs()
+	for b.Loop() {
+		func() {
+			defer policy.Guard()
+		}()
+	}
+}
+
+func BenchmarkTackleCachedNoPanic(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		func() {
+			defer nice.TackleCached(errBenchmark).With(noop)
+		}()
+	}
+}
+
+func BenchmarkHandleNoPanic(b *testing.B) {
+	policy := nice.Route(
+		nice.On(errBenchmark, noop),
+		nice.On(reflect.TypeFor[string](), noop),
+	)
+	b.ReportAllocs()
+	for b.Loop() {
+		func() {
+			defer nice.Handle(policy)
+		}()
+	}
+}
+
+func BenchmarkTacklePanic(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		func() {
+			defer nice.Tackle(errBenchmark).With(noop)
+			panic(errBenchmark)
+		}()
+	}
+}
+
+func BenchmarkDispatchManyTargets(b *testing.B) {
+	targets := make([]any, 0, 64)
+	for i := range 63 {
+		targets = append(targets, fmt.Errorf("target %d", i))
+	}
+	targets = append(targets, errBenchmark)
+	handler := nice.Tackle(targets...)
+	b.ReportAllocs()
+	for b.Loop() {
+		func() {
+			defer handler.With(noop)
+			panic(errBenchmark)
+		}()
+	}
+}
+
+// TestAllocationBudget keeps the path without panic free of allocations,
+// for handlers built ahead of the protected call.
+func TestAllocationBudget(t *testing.T) {
+	handler := nice.Tackle(errBenchmark)
+	policy := nice.Route(nice.On(errBenchmark, noop))
+	budgets := map[string]func(){
+		"Handler.With": func() { defer handler.With(noop) },
+		"Tackle()":     func() { defer nice.Tackle().With(noop) },
+		"Policy.Guard": func() { defer policy.Guard() },
+		"Handle":       func() { defer nice.Handle(policy) },
+	}
+	for name, protected := range budgets {
+		if allocs := testing.AllocsPerRun(100, protected); allocs > 0 {
+			t.Errorf("%s allocates %v times without panic, want 0", name, allocs)
+		}
+	}
+}
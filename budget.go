@@ -0,0 +1,68 @@
+package nice
+
+import (
+	"sync"
+	"time"
+)
+
+// Budget of panics the process may recover from within a sliding window,
+// before it is considered unhealthy, e.g. stuck in recover-crash cycles.
+// It is a Reporter, counting every dispatched event once added by AddReporter.
+//
+//	budget := nice.NewBudget(10, time.Minute)
+//	nice.AddReporter(budget)
+type Budget struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	times  []time.Time
+	now    func() time.Time
+}
+
+// NewBudget returns a Budget of limit panics per window.
+func NewBudget(limit int, window time.Duration) *Budget {
+	return &Budget{limit: limit, window: window, now: clockNow}
+}
+
+// Report counts the event against the budget.
+func (b *Budget) Report(PanicEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.times = append(b.expire(), b.now())
+}
+
+// Spent returns the number of panics within the current window.
+func (b *Budget) Spent() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.times = b.expire()
+	return len(b.times)
+}
+
+// Exhausted tells whether more panics than the limit occurred within the current window.
+func (b *Budget) Exhausted() bool {
+	return b.Spent() > b.limit
+}
+
+// ExhaustedFor returns how long the budget stays exhausted without further panics, zero if it is not exhausted.
+func (b *Budget) ExhaustedFor() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.times = b.expire()
+	// The budget is exhausted until the panic beyond the limit ages out, with the earlier ones.
+	i := len(b.times) - b.limit - 1
+	if i < 0 || i >= len(b.times) {
+		return 0
+	}
+	return b.times[i].Add(b.window).Sub(b.now())
+}
+
+// expire drops the times out of the window. It shall be called with the mutex locked.
+func (b *Budget) expire() []time.Time {
+	since := b.now().Add(-b.window)
+	i := 0
+	for i < len(b.times) && !b.times[i].After(since) {
+		i++
+	}
+	return b.times[i:]
+}
@@ -0,0 +1,40 @@
+package nice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudget(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	budget := NewBudget(2, time.Minute)
+	budget.now = func() time.Time { return now }
+
+	budget.Report(PanicEvent{})
+	budget.Report(PanicEvent{})
+	assert.Equal(t, 2, budget.Spent())
+	assert.False(t, budget.Exhausted())
+
+	assert.Zero(t, budget.ExhaustedFor())
+
+	now = now.Add(30 * time.Second)
+	budget.Report(PanicEvent{})
+	assert.True(t, budget.Exhausted())
+	assert.Equal(t, 30*time.Second, budget.ExhaustedFor(), "The budget is exhausted until the first panic ages out.")
+
+	now = now.Add(40 * time.Second)
+	assert.Equal(t, 1, budget.Spent(), "Panics out of the window are not counted.")
+	assert.False(t, budget.Exhausted())
+	assert.Zero(t, budget.ExhaustedFor())
+}
+
+func TestBudgetReporter(t *testing.T) {
+	cleanRegistry(t)
+	budget := NewBudget(0, time.Minute)
+	AddReporter(budget)
+
+	assert.Panics(t, func() { guarded("unhandled") })
+	assert.True(t, budget.Exhausted())
+}
@@ -0,0 +1,67 @@
+package nice
+
+import "slices"
+
+// Builder constructs a Policy step by step.
+//
+//	defer nice.New().
+//		On(ErrNotFound).Do(respondNotFound).
+//		On(nice.Type[*TimeoutError]()).Do(respondTimeout).
+//		Default(respondInternal).
+//		Guard()
+//
+// Go has no generic methods, so the type targets are given as reflect.Type.
+type Builder struct {
+	policy Policy
+}
+
+// Step is a pending clause of a Builder, waiting for its handle func.
+type Step struct {
+	builder *Builder
+	targets []any
+}
+
+// New returns an empty Builder.
+func New() *Builder {
+	return &Builder{}
+}
+
+// On begins a clause for the targets, accepted as by Tackle.
+func (b *Builder) On(targets ...any) *Step {
+	return &Step{builder: b, targets: targets}
+}
+
+// Do completes the clause with its handle func.
+func (s *Step) Do(handle func(artefact any)) *Builder {
+	b := s.builder
+	h := Tackle(s.targets...)
+	b.policy.pairs = append(b.policy.pairs, On(h, handle))
+	b.policy.handlers = append(b.policy.handlers, h)
+	return b
+}
+
+// Default sets the handle func for the artefact matched by no clause.
+func (b *Builder) Default(handle func(artefact any)) *Builder {
+	b.policy = b.policy.Default(handle)
+	return b
+}
+
+// Policy returns the built Policy.
+// Further clauses on the Builder do not change a returned Policy.
+func (b *Builder) Policy() Policy {
+	p := b.policy
+	p.pairs = slices.Clip(p.pairs)
+	p.handlers = slices.Clip(p.handlers)
+	return p
+}
+
+// Guard recovers panic and handles it by the built Policy.
+// It shall be deferred directly.
+func (b *Builder) Guard() {
+	if RecoveryDisabled {
+		return
+	}
+	if artefact := recover(); artefact != nil {
+		b.policy.tackle(artefact)
+	}
+}
@@ -0,0 +1,54 @@
+package nice_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/stretchr/testify/assert"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "timeout" }
+
+func TestBuilder(t *testing.T) {
+	errFoo := errors.New("foo")
+	built := func(artefact any) (executed string) {
+		defer nice.New().
+			On(errFoo).Do(func(any) { executed = "foo" }).
+			On(nice.Type[timeoutError]()).Do(func(any) { executed = "timeout" }).
+			Default(func(any) { executed = "default" }).
+			Guard()
+		panic(artefact)
+	}
+
+	assert.Equal(t, "foo", built(errFoo))
+	assert.Equal(t, "timeout", built(timeoutError{}))
+	assert.Equal(t, "default", built("anything"))
+}
+
+func TestBuilderWithoutDefault(t *testing.T) {
+	b := nice.New().On(reflect.TypeFor[string]()).Do(func(any) {})
+
+	assert.NotPanics(t, func() {
+		defer b.Guard()
+		panic("message")
+	})
+	assert.Panics(t, func() {
+		defer b.Guard()
+		panic(7)
+	}, "Unmatched panic falls through without a default.")
+}
+
+func TestBuilderPolicy(t *testing.T) {
+	b := nice.New().On(reflect.TypeFor[string]()).Do(func(any) {})
+	p := b.Policy()
+	b.On(reflect.TypeFor[int]()).Do(func(any) {})
+
+	assert.Panics(t, func() {
+		defer p.Guard()
+		panic(7)
+	}, "Later clauses do not change the returned Policy.")
+}
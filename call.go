@@ -0,0 +1,12 @@
+package nice
+
+import "reflect"
+
+// SafeCall calls fn by reflect as fn.Call, returning the panic of the call as a *PanicError,
+// whether raised by reflect, e.g. for a wrong number or type of arguments or a fn which is not a func,
+// or by fn itself, so plugin hosts and RPC dispatchers isolate their bad handlers.
+//
+//	results, err := nice.SafeCall(method, reflect.ValueOf(ctx), reflect.ValueOf(req))
+func SafeCall(fn reflect.Value, args ...reflect.Value) ([]reflect.Value, error) {
+	return ProtectResult(func() []reflect.Value { return fn.Call(args) })
+}
@@ -0,0 +1,40 @@
+package nice
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeCall(t *testing.T) {
+	atoi := reflect.ValueOf(strconv.Atoi)
+
+	results, err := SafeCall(atoi, reflect.ValueOf("12"))
+	assert.NoError(t, err)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, 12, results[0].Interface())
+	}
+
+	for name, call := range map[string]func() ([]reflect.Value, error){
+		"wrong arity":   func() ([]reflect.Value, error) { return SafeCall(atoi) },
+		"type mismatch": func() ([]reflect.Value, error) { return SafeCall(atoi, reflect.ValueOf(12)) },
+		"not a func":    func() ([]reflect.Value, error) { return SafeCall(reflect.ValueOf(12)) },
+		"zero value":    func() ([]reflect.Value, error) { return SafeCall(reflect.Value{}) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			results, err := call()
+			var panicErr *PanicError
+			assert.ErrorAs(t, err, &panicErr)
+			assert.Nil(t, results)
+		})
+	}
+
+	t.Run("panicking target", func(t *testing.T) {
+		errTarget := errors.New("target")
+		_, err := SafeCall(reflect.ValueOf(func(...int) { panic(errTarget) }), reflect.ValueOf(1), reflect.ValueOf(2))
+		assert.ErrorIs(t, err, errTarget)
+	})
+}
@@ -0,0 +1,28 @@
+package nice
+
+import "context"
+
+// GuardCallback calls fn for a Go function invoked from a C callback, so its panic never unwinds
+// across the cgo boundary, which the runtime cannot survive: the panic is recovered,
+// whether handled or not, and failure is returned to C instead of the result of fn, e.g. an error code.
+// The panic is dispatched to the globally registered handlers and reporters,
+// with the name of the callback recorded as cgo_callback in the event metadata.
+//
+//	//export onFrame
+//	func onFrame(data *C.uint8_t, n C.size_t) C.int {
+//		return nice.GuardCallback("onFrame", C.int(-1), func() C.int {
+//			return decode(unsafe.Slice((*byte)(data), n))
+//		})
+//	}
+func GuardCallback[T any](name string, failure T, fn func() T) (result T) {
+	defer func() {
+		if RecoveryDisabled {
+			return
+		}
+		if artefact := recover(); artefact != nil {
+			recoverWith(context.Background(), artefact, map[string]string{"cgo_callback": name}, "GuardCallback")
+			result = failure
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,17 @@
+package nice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuardCallback(t *testing.T) {
+	events := reported(t)
+
+	assert.Equal(t, 0, GuardCallback("onFrame", -1, func() int { return 0 }))
+	assert.Equal(t, -1, GuardCallback("onFrame", -1, func() int { panic("bad frame") }), "unhandled panics too")
+	if assert.Len(t, events.events, 1) {
+		assert.Equal(t, "onFrame", events.events[0].Metadata["cgo_callback"])
+	}
+}
@@ -0,0 +1,34 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterChained(t *testing.T) {
+	cleanRegistry(t)
+	errDeclined := errors.New("declined")
+	var calls []string
+	RegisterChained(reflect.TypeFor[error](), func(event PanicEvent) HandleResult {
+		calls = append(calls, "audit")
+		return Continue
+	}, Named("audit"))
+	RegisterChained(errDeclined, func(event PanicEvent) HandleResult {
+		calls = append(calls, "payments")
+		return Stop
+	})
+	Register(reflect.TypeFor[error](), func(any) { calls = append(calls, "generic") })
+
+	event := Dispatch(context.Background(), errDeclined)
+
+	assert.True(t, event.Handled)
+	assert.Equal(t, []string{"audit", "payments"}, calls, "the chain continues to the next matching handler until Stop")
+
+	calls = nil
+	Dispatch(context.Background(), errors.New("other"))
+	assert.Equal(t, []string{"audit", "generic"}, calls, "plain handlers stop the chain")
+}
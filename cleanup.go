@@ -0,0 +1,42 @@
+package nice
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// AddCleanup attaches the cleanup to ptr as runtime.AddCleanup does,
+// with the cleanup protected.
+// A panic in the cleanup is dispatched to the globally registered handlers and reporters,
+// with the type of ptr recorded as "owner_type" in the event metadata.
+// It never falls through, because a panic in the cleanup goroutine
+// would crash the process at an unpredictable time, unless in dry-run mode.
+func AddCleanup[T, S any](ptr *T, cleanup func(S), arg S) runtime.Cleanup {
+	owner := reflect.TypeFor[*T]().String()
+	return runtime.AddCleanup(ptr, func(arg S) {
+		defer tackleCleanup(owner)
+		cleanup(arg)
+	}, arg)
+}
+
+// SetFinalizer sets the finalizer of obj as runtime.SetFinalizer does,
+// with the finalizer protected the same way as AddCleanup.
+func SetFinalizer[T any](obj *T, finalizer func(*T)) {
+	owner := reflect.TypeFor[*T]().String()
+	runtime.SetFinalizer(obj, func(obj *T) {
+		defer tackleCleanup(owner)
+		finalizer(obj)
+	})
+}
+
+func tackleCleanup(owner string) {
+	if RecoveryDisabled {
+		return
+	}
+	if artefact := recover(); artefact != nil {
+		event := newEvent(artefact)
+		event.Metadata = map[string]string{"owner_type": owner}
+		dispatchRegistered(event)
+		reraise(artefact)
+	}
+}
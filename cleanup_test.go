@@ -0,0 +1,64 @@
+package nice
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cleanupOwner struct {
+	buf [64]byte
+}
+
+func awaitEvent(t *testing.T, events <-chan PanicEvent) PanicEvent {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case event := <-events:
+			return event
+		case <-deadline:
+			t.Fatal("Cleanup has not been run.")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+type chanReporter chan PanicEvent
+
+func (r chanReporter) Report(event PanicEvent) {
+	r <- event
+}
+
+func TestAddCleanup(t *testing.T) {
+	cleanRegistry(t)
+	events := make(chanReporter, 1)
+	AddReporter(events)
+
+	func() {
+		owner := &cleanupOwner{}
+		AddCleanup(owner, func(msg string) { panic(msg) }, "cleanup failed")
+	}()
+
+	event := awaitEvent(t, events)
+	assert.Equal(t, "cleanup failed", event.Artefact)
+	assert.Equal(t, "*nice.cleanupOwner", event.Metadata["owner_type"])
+}
+
+func TestSetFinalizer(t *testing.T) {
+	cleanRegistry(t)
+	events := make(chanReporter, 1)
+	AddReporter(events)
+
+	func() {
+		owner := &cleanupOwner{}
+		SetFinalizer(owner, func(*cleanupOwner) { panic("finalizer failed") })
+	}()
+
+	event := awaitEvent(t, events)
+	assert.Equal(t, "finalizer failed", event.Artefact)
+	assert.Equal(t, "*nice.cleanupOwner", event.Metadata["owner_type"])
+}
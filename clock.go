@@ -0,0 +1,83 @@
+package nice
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Clock tells the time to the time-dependent features: the event times, the rate limits,
+// the storm windows, the budgets, the profile intervals and the backoff of Sleep, Retry and Supervise.
+// It is the system clock unless set by SetClock, e.g. to the fake of nicetest,
+// so the time-based behaviour can be tested deterministically.
+// The durations and deadlines of the handlers always run on the system clock,
+// as they measure the handlers themselves.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer returns a Timer sending the time on its channel once the duration elapsed.
+	NewTimer(d time.Duration) Timer
+	// AfterFunc calls f in its own goroutine once the duration elapsed.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer of a Clock, as time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is sent.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, and reports whether it stopped it.
+	Stop() bool
+}
+
+// clock holds the Clock set by SetClock.
+var clock atomic.Pointer[Clock]
+
+// SetClock sets the Clock of the time-dependent features.
+// A nil clock restores the system clock.
+// It is meant for tests, and shall be restored once done, as it applies to the whole process.
+func SetClock(c Clock) {
+	if c == nil {
+		clock.Store(nil)
+		return
+	}
+	clock.Store(&c)
+}
+
+// currentClock returns the Clock set by SetClock, or the system clock.
+func currentClock() Clock {
+	if c := clock.Load(); c != nil {
+		return *c
+	}
+	return systemClock{}
+}
+
+// clockNow returns the current time of the Clock.
+func clockNow() time.Time {
+	return currentClock().Now()
+}
+
+// systemClock is the Clock of the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return systemTimer{time.AfterFunc(d, f)}
+}
+
+type systemTimer struct {
+	timer *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t systemTimer) Stop() bool {
+	return t.timer.Stop()
+}
@@ -0,0 +1,36 @@
+// Package example has the artefact types generated for by nicegen, as an example and for its tests.
+package example
+
+import "fmt"
+
+//go:generate go run github.com/antonyho/nice/cmd/nicegen
+
+// TimeoutError is tackled as a pointer, as its Error method has a pointer receiver.
+//
+//nice:artefact
+type TimeoutError struct {
+	Op string
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s timed out", e.Op)
+}
+
+// Code is an artefact which is not an error.
+//
+//nice:artefact
+type Code int
+
+// retryable is unexported, and so are its generated funcs.
+//
+//nice:artefact
+type retryable struct {
+	attempt int
+}
+
+func (r retryable) Error() string {
+	return fmt.Sprintf("attempt %d failed", r.attempt)
+}
+
+// Ignored has no directive.
+type Ignored struct{}
@@ -0,0 +1,40 @@
+package example
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerated(t *testing.T) {
+	var timedOut *TimeoutError
+	func() {
+		defer TackleTimeoutError().With(HandleTimeoutError(func(err *TimeoutError) { timedOut = err }))
+		panic(&TimeoutError{Op: "dial"})
+	}()
+	assert.Equal(t, "dial", timedOut.Op)
+
+	var code Code
+	func() {
+		defer nice.Route(
+			nice.On(TackleCode(), HandleCode(func(c Code) { code = c })),
+		).Guard()
+		panic(Code(7))
+	}()
+	assert.Equal(t, Code(7), code)
+
+	var attempt int
+	func() {
+		defer tackleRetryable().With(handleRetryable(func(r retryable) { attempt = r.attempt }))
+		panic(retryable{attempt: 3})
+	}()
+	assert.Equal(t, 3, attempt)
+
+	assert.PanicsWithValue(t, Code(1), func() {
+		defer TackleTimeoutError().With(HandleTimeoutError(func(*TimeoutError) {}))
+		panic(Code(1))
+	})
+	assert.Equal(t, "type *example.TimeoutError", nice.Explain(errors.New("x"), timeoutErrorTarget{}).Evaluations[0].Target)
+}
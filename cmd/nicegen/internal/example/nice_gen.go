@@ -0,0 +1,86 @@
+// Code generated by nicegen. DO NOT EDIT.
+
+package example
+
+import "github.com/antonyho/nice"
+
+// codeTarget matches artefacts of type Code.
+type codeTarget struct{}
+
+func (codeTarget) Match(artefact any) bool {
+	_, matched := artefact.(Code)
+	return matched
+}
+
+func (codeTarget) String() string {
+	return "type example.Code"
+}
+
+// TackleCode returns a Handler for artefacts of type Code.
+func TackleCode() nice.Handler {
+	return nice.Tackle(codeTarget{})
+}
+
+// HandleCode adapts the handle func of Code to the handle func of artefacts.
+// Artefacts of other types are ignored.
+func HandleCode(handle func(artefact Code)) func(artefact any) {
+	return func(artefact any) {
+		if t, matched := artefact.(Code); matched {
+			handle(t)
+		}
+	}
+}
+
+// timeoutErrorTarget matches artefacts of type *TimeoutError.
+type timeoutErrorTarget struct{}
+
+func (timeoutErrorTarget) Match(artefact any) bool {
+	_, matched := artefact.(*TimeoutError)
+	return matched
+}
+
+func (timeoutErrorTarget) String() string {
+	return "type *example.TimeoutError"
+}
+
+// TackleTimeoutError returns a Handler for artefacts of type *TimeoutError.
+func TackleTimeoutError() nice.Handler {
+	return nice.Tackle(timeoutErrorTarget{})
+}
+
+// HandleTimeoutError adapts the handle func of *TimeoutError to the handle func of artefacts.
+// Artefacts of other types are ignored.
+func HandleTimeoutError(handle func(artefact *TimeoutError)) func(artefact any) {
+	return func(artefact any) {
+		if t, matched := artefact.(*TimeoutError); matched {
+			handle(t)
+		}
+	}
+}
+
+// retryableTarget matches artefacts of type retryable.
+type retryableTarget struct{}
+
+func (retryableTarget) Match(artefact any) bool {
+	_, matched := artefact.(retryable)
+	return matched
+}
+
+func (retryableTarget) String() string {
+	return "type example.retryable"
+}
+
+// tackleRetryable returns a Handler for artefacts of type retryable.
+func tackleRetryable() nice.Handler {
+	return nice.Tackle(retryableTarget{})
+}
+
+// handleRetryable adapts the handle func of retryable to the handle func of artefacts.
+// Artefacts of other types are ignored.
+func handleRetryable(handle func(artefact retryable)) func(artefact any) {
+	return func(artefact any) {
+		if t, matched := artefact.(retryable); matched {
+			handle(t)
+		}
+	}
+}
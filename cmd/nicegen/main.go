@@ -0,0 +1,255 @@
+/*
+Nicegen generates typed Tackle and Handle funcs for the artefact types of a package,
+so handling project-specific types needs neither reflect nor type assertions.
+
+Annotate the types with the nice:artefact directive, and run nicegen by go generate:
+
+	//go:generate go run github.com/antonyho/nice/cmd/nicegen
+
+	// TimeoutError is raised when the upstream times out.
+	//
+	//nice:artefact
+	type TimeoutError struct{ ... }
+
+For each type X, it generates TackleX, returning a nice.Handler for artefacts of X,
+and HandleX, adapting a handle func of X to the handle funcs of nice.Handler.With:
+
+	defer TackleTimeoutError().With(HandleTimeoutError(func(err *TimeoutError) { retry(err) }))
+
+An error type whose Error method has a pointer receiver is tackled as the pointer.
+
+Usage:
+
+	nicegen [-output file] [dir]
+
+The package in dir, by default the current directory, is scanned,
+and the funcs are written to the output file in dir, by default nice_gen.go.
+*/
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// directive annotating the artefact types.
+const directive = "//nice:artefact"
+
+// DefaultOutput is the name of the generated file.
+const DefaultOutput = "nice_gen.go"
+
+func main() {
+	output := flag.String("output", DefaultOutput, "name of the generated file in the package directory")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: nicegen [-output file] [dir]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	if err := generate(dir, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "nicegen:", err)
+		os.Exit(1)
+	}
+}
+
+// artefact is an annotated type.
+type artefact struct {
+	// Name of the type.
+	Name string
+	// Type as written in the generated code, e.g. *TimeoutError.
+	Type string
+	// Package name, for describing the type.
+	Package string
+}
+
+// Exported tells whether the type is exported, and so are its generated funcs.
+func (a artefact) Exported() bool {
+	return ast.IsExported(a.Name)
+}
+
+// Suffix of the generated funcs.
+func (a artefact) Suffix() string {
+	runes := []rune(a.Name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// Target is the name of the generated matcher type.
+func (a artefact) Target() string {
+	runes := []rune(a.Name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes) + "Target"
+}
+
+// generate writes the funcs of the annotated types of the package in dir to the output file.
+// The output file is removed if no type is annotated.
+func generate(dir, output string) error {
+	pkg, artefacts, err := scan(dir, output)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, output)
+	if len(artefacts) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	src, err := render(pkg, artefacts)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, src, 0o644)
+}
+
+// scan the package in dir, but for its tests and the output file, for the annotated types.
+func scan(dir, output string) (pkg string, artefacts []artefact, err error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+	fset := token.NewFileSet()
+	var parsed []*ast.File
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") || filepath.Base(name) == output {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, err
+		}
+		if pkg != "" && f.Name.Name != pkg {
+			return "", nil, fmt.Errorf("%s: package %s, expected %s", name, f.Name.Name, pkg)
+		}
+		pkg = f.Name.Name
+		parsed = append(parsed, f)
+	}
+	if pkg == "" {
+		return "", nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	pointerErrors := make(map[string]bool)
+	for _, f := range parsed {
+		for _, decl := range f.Decls {
+			if fn, isFunc := decl.(*ast.FuncDecl); isFunc {
+				if name, pointer := errorReceiver(fn); name != "" {
+					pointerErrors[name] = pointer
+				}
+			}
+		}
+	}
+	for _, f := range parsed {
+		for _, decl := range f.Decls {
+			gen, isGen := decl.(*ast.GenDecl)
+			if !isGen || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				if !annotated(spec.Doc) && !(len(gen.Specs) == 1 && annotated(gen.Doc)) {
+					continue
+				}
+				if spec.TypeParams != nil {
+					return "", nil, fmt.Errorf("%s: generic type %s cannot be annotated", fset.Position(spec.Pos()), spec.Name.Name)
+				}
+				a := artefact{Name: spec.Name.Name, Type: spec.Name.Name, Package: pkg}
+				if pointerErrors[a.Name] {
+					a.Type = "*" + a.Name
+				}
+				artefacts = append(artefacts, a)
+			}
+		}
+	}
+	slices.SortFunc(artefacts, func(a, b artefact) int { return strings.Compare(a.Name, b.Name) })
+	return pkg, artefacts, nil
+}
+
+// annotated tells whether the doc comment has the directive.
+func annotated(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if c.Text == directive || strings.HasPrefix(c.Text, directive+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// errorReceiver returns the receiver type of an Error method, and whether it is a pointer.
+func errorReceiver(fn *ast.FuncDecl) (name string, pointer bool) {
+	if fn.Recv == nil || len(fn.Recv.List) != 1 || fn.Name.Name != "Error" {
+		return "", false
+	}
+	recv := fn.Recv.List[0].Type
+	if star, isStar := recv.(*ast.StarExpr); isStar {
+		recv, pointer = star.X, true
+	}
+	if ident, isIdent := recv.(*ast.Ident); isIdent {
+		return ident.Name, pointer
+	}
+	return "", false
+}
+
+// render the generated file, formatted.
+func render(pkg string, artefacts []artefact) ([]byte, error) {
+	var b bytes.Buffer
+	if err := generated.Execute(&b, struct {
+		Package   string
+		Artefacts []artefact
+	}{pkg, artefacts}); err != nil {
+		return nil, err
+	}
+	return format.Source(b.Bytes())
+}
+
+var generated = template.Must(template.New(DefaultOutput).Parse(`// Code generated by nicegen. DO NOT EDIT.
+
+package {{.Package}}
+
+import "github.com/antonyho/nice"
+{{range .Artefacts}}
+// {{.Target}} matches artefacts of type {{.Type}}.
+type {{.Target}} struct{}
+
+func ({{.Target}}) Match(artefact any) bool {
+	_, matched := artefact.({{.Type}})
+	return matched
+}
+
+func ({{.Target}}) String() string {
+	return "type {{if ne .Type .Name}}*{{end}}{{.Package}}.{{.Name}}"
+}
+
+// {{if .Exported}}Tackle{{else}}tackle{{end}}{{.Suffix}} returns a Handler for artefacts of type {{.Type}}.
+func {{if .Exported}}Tackle{{else}}tackle{{end}}{{.Suffix}}() nice.Handler {
+	return nice.Tackle({{.Target}}{})
+}
+
+// {{if .Exported}}Handle{{else}}handle{{end}}{{.Suffix}} adapts the handle func of {{.Type}} to the handle func of artefacts.
+// Artefacts of other types are ignored.
+func {{if .Exported}}Handle{{else}}handle{{end}}{{.Suffix}}(handle func(artefact {{.Type}})) func(artefact any) {
+	return func(artefact any) {
+		if t, matched := artefact.({{.Type}}); matched {
+			handle(t)
+		}
+	}
+}
+{{end}}`))
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	src, err := os.ReadFile(filepath.Join("internal", "example", "example.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "example.go"), src, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, generate(dir, DefaultOutput))
+
+	generated, _ := os.ReadFile(filepath.Join(dir, DefaultOutput))
+	committed, err := os.ReadFile(filepath.Join("internal", "example", DefaultOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(committed), string(generated), "the example is generated by the current nicegen")
+}
+
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, src string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.go", `package a
+
+//nice:artefact
+type ValueError struct{}
+
+func (ValueError) Error() string { return "value" }
+
+type (
+	//nice:artefact
+	PointerError struct{}
+
+	Plain struct{}
+)
+
+func (*PointerError) Error() string { return "pointer" }
+`)
+	write("a_test.go", "package a_test\n\n//nice:artefact\ntype Tested struct{}\n")
+
+	pkg, artefacts, err := scan(dir, DefaultOutput)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", pkg)
+	assert.Equal(t, []artefact{
+		{Name: "PointerError", Type: "*PointerError", Package: "a"},
+		{Name: "ValueError", Type: "ValueError", Package: "a"},
+	}, artefacts, "tests and types without the directive are skipped")
+
+	write("b.go", "package a\n\n//nice:artefact\ntype Generic[T any] struct{}\n")
+	_, _, err = scan(dir, DefaultOutput)
+	assert.ErrorContains(t, err, "generic type Generic")
+
+	empty := t.TempDir()
+	_, _, err = scan(empty, DefaultOutput)
+	assert.Error(t, err)
+}
+
+func TestGenerateNothing(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.go", DefaultOutput} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("package a\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.NoError(t, generate(dir, DefaultOutput))
+
+	assert.NoFileExists(t, filepath.Join(dir, DefaultOutput), "a stale output is removed")
+}
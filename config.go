@@ -0,0 +1,158 @@
+package nice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// EnvDisable is the environment variable listing the names of handlers and reporters
+// to be disabled, separated by comma, e.g. `NICE_DISABLE=sentry,webhook`.
+// It is evaluated at startup and on every Reload, and overrides the Config.
+const EnvDisable = "NICE_DISABLE"
+
+func init() {
+	Reload(Config{})
+}
+
+// Config of the global registry, applied with Reload.
+// Handlers and reporters are configured by the name given with Named or RegisterOnce.
+// Unnamed registrations are not configurable.
+type Config struct {
+	Handlers map[string]HandlerConfig `json:"handlers,omitempty"`
+}
+
+// HandlerConfig is the configuration of a named handler or reporter.
+type HandlerConfig struct {
+	// Disabled handlers are skipped while matching, disabled reporters receive no event.
+	Disabled bool `json:"disabled,omitempty"`
+	// Severity is stamped into the events handled by the handler.
+	Severity Severity `json:"severity,omitempty"`
+	// RateLimit is the number of events per second the handler or reporter is called for.
+	// An event over the limit is still handled, without calling the handle func,
+	// and is marked with "rate_limited" metadata. Zero is unlimited.
+	RateLimit float64 `json:"rate_limit,omitempty"`
+	// Burst is the number of events allowed at once over the rate limit.
+	// Defaults to the rate limit rounded up.
+	Burst int `json:"burst,omitempty"`
+}
+
+// runtimeConfig is the Config in effect with the state of its rate limiters.
+type runtimeConfig struct {
+	handlers map[string]HandlerConfig
+	limiters map[string]*rateLimiter
+}
+
+func (c runtimeConfig) settings(name string) HandlerConfig {
+	if name == "" {
+		return HandlerConfig{}
+	}
+	return c.handlers[name]
+}
+
+func (c runtimeConfig) allow(name string) bool {
+	limiter, limited := c.limiters[name]
+	return !limited || limiter.allow(clockNow())
+}
+
+// Reload replaces the configuration of the global registry,
+// without restarting the process.
+// The rate limits start over.
+// Handlers and reporters listed in EnvDisable are disabled regardless of the Config.
+func Reload(cfg Config) {
+	config := runtimeConfig{
+		handlers: make(map[string]HandlerConfig, len(cfg.Handlers)),
+		limiters: make(map[string]*rateLimiter),
+	}
+	for name, settings := range cfg.Handlers {
+		config.handlers[name] = settings
+		if settings.RateLimit > 0 {
+			config.limiters[name] = newRateLimiter(settings.RateLimit, settings.Burst)
+		}
+	}
+	for _, name := range strings.Split(os.Getenv(EnvDisable), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			settings := config.handlers[name]
+			settings.Disabled = true
+			config.handlers[name] = settings
+		}
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	updateRegistry(func(s *registrySnapshot) {
+		s.config = config
+	})
+}
+
+// LoadConfig decodes a JSON Config.
+func LoadConfig(r io.Reader) (Config, error) {
+	var cfg Config
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("decode config: %w", err)
+	}
+	return cfg, nil
+}
+
+// WatchConfig loads the JSON Config from the file at path and applies it with Reload.
+// It returns the error of the initial load.
+// The file is then polled at the interval until the context is done,
+// and reloaded whenever it is modified.
+// A modified file which fails to load is reported to onError, if not nil,
+// and the configuration in effect is kept.
+func WatchConfig(ctx context.Context, path string, interval time.Duration, onError func(error)) error {
+	modified, err := reloadFile(path)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			info, err := os.Stat(path)
+			if err == nil && info.ModTime().Equal(modified) {
+				continue
+			}
+			if err == nil {
+				modified, err = reloadFile(path)
+			}
+			if err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}()
+	return nil
+}
+
+// reloadFile applies the config file and returns its modification time.
+func reloadFile(path string) (time.Time, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return time.Time{}, err
+	}
+	cfg, err := LoadConfig(f)
+	if err != nil {
+		return info.ModTime(), fmt.Errorf("%s: %w", path, err)
+	}
+	Reload(cfg)
+	return info.ModTime(), nil
+}
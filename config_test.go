@@ -0,0 +1,140 @@
+package nice
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func guarded(artefact any) {
+	defer Guard()
+	panic(artefact)
+}
+
+func TestReload(t *testing.T) {
+	t.Run("disabled handler", func(t *testing.T) {
+		cleanRegistry(t)
+		var executed []string
+		Register(reflect.TypeFor[string](), func(any) { executed = append(executed, "primary") }, Named("primary"))
+		Register(reflect.TypeFor[string](), func(any) { executed = append(executed, "fallback") })
+
+		Reload(Config{Handlers: map[string]HandlerConfig{"primary": {Disabled: true}}})
+		guarded("disabled")
+		Reload(Config{})
+		guarded("enabled")
+
+		assert.Equal(t, []string{"fallback", "primary"}, executed)
+	})
+
+	t.Run("disabled reporter", func(t *testing.T) {
+		cleanRegistry(t)
+		reporter := &mockReporter{}
+		AddReporter(reporter, Named("mock"))
+		Register(Tackle(reflect.TypeFor[string]()), func(any) {})
+
+		Reload(Config{Handlers: map[string]HandlerConfig{"mock": {Disabled: true}}})
+		guarded("silenced")
+
+		assert.Empty(t, reporter.events)
+	})
+
+	t.Run("severity", func(t *testing.T) {
+		cleanRegistry(t)
+		reporter := &mockReporter{}
+		AddReporter(reporter)
+		Register(reflect.TypeFor[string](), func(any) {}, Named("critical"))
+
+		Reload(Config{Handlers: map[string]HandlerConfig{"critical": {Severity: SeverityCritical}}})
+		guarded("severe")
+
+		if assert.Len(t, reporter.events, 1) {
+			assert.Equal(t, SeverityCritical, reporter.events[0].Severity)
+		}
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		cleanRegistry(t)
+		executed := 0
+		Register(reflect.TypeFor[string](), func(any) { executed++ }, Named("limited"))
+
+		Reload(Config{Handlers: map[string]HandlerConfig{"limited": {RateLimit: 0.001, Burst: 2}}})
+		for range 5 {
+			guarded("flood")
+		}
+
+		assert.Equal(t, 2, executed, "Events over the limit are handled without calling the handle func.")
+	})
+}
+
+func TestEnvDisable(t *testing.T) {
+	cleanRegistry(t)
+	t.Setenv(EnvDisable, "sentry, webhook")
+	reporters := map[string]*mockReporter{"sentry": {}, "webhook": {}, "log": {}}
+	for name, r := range reporters {
+		AddReporter(r, Named(name))
+	}
+	Register(reflect.TypeFor[string](), func(any) {})
+
+	Reload(Config{Handlers: map[string]HandlerConfig{"webhook": {Severity: SeverityCritical}}})
+	guarded("silenced")
+
+	assert.Empty(t, reporters["sentry"].events)
+	assert.Empty(t, reporters["webhook"].events)
+	assert.Len(t, reporters["log"].events, 1)
+	assert.Equal(t, SeverityCritical, loadRegistry().config.settings("webhook").Severity,
+		"The rest of the config is kept.")
+}
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := LoadConfig(strings.NewReader(`{
+		"handlers": {
+			"webhook": {"disabled": true},
+			"log": {"severity": "warning", "rate_limit": 10, "burst": 20}
+		}
+	}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, Config{Handlers: map[string]HandlerConfig{
+		"webhook": {Disabled: true},
+		"log":     {Severity: SeverityWarning, RateLimit: 10, Burst: 20},
+	}}, cfg)
+
+	_, err = LoadConfig(strings.NewReader(`{"handlers": {"log": {"severity": "loud"}}}`))
+	assert.Error(t, err)
+}
+
+func TestWatchConfig(t *testing.T) {
+	cleanRegistry(t)
+	path := filepath.Join(t.TempDir(), "nice.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{}`), 0o600))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.NoError(t, WatchConfig(ctx, path, time.Millisecond, nil))
+	later := time.Now().Add(time.Second)
+	assert.NoError(t, os.WriteFile(path, []byte(`{"handlers": {"log": {"disabled": true}}}`), 0o600))
+	assert.NoError(t, os.Chtimes(path, later, later))
+
+	assert.Eventually(t, func() bool {
+		return loadRegistry().config.settings("log").Disabled
+	}, time.Second, time.Millisecond)
+
+	assert.Error(t, WatchConfig(ctx, filepath.Join(t.TempDir(), "missing.json"), time.Millisecond, nil))
+}
+
+func TestSeverityText(t *testing.T) {
+	for _, s := range []Severity{SeverityDefault, SeverityDebug, SeverityInfo, SeverityWarning, SeverityError, SeverityCritical} {
+		text, err := s.MarshalText()
+		assert.NoError(t, err)
+
+		var decoded Severity
+		assert.NoError(t, decoded.UnmarshalText(text))
+		assert.Equal(t, s, decoded)
+	}
+}
@@ -0,0 +1,149 @@
+package nice
+
+import (
+	"context"
+)
+
+type handlersKey struct{}
+
+type metadataKey struct{}
+
+// contextHandlers are the pairs stored in a context, innermost first.
+type contextHandlers struct {
+	pairs         []Pair
+	registrations []registration
+}
+
+// WithHandlers returns a copy of the context carrying the pairs,
+// which are consulted ahead of the globally registered handlers
+// by GuardContext, Dispatch and the middleware of nicehttp.
+// Pairs of the parent context are kept, and consulted after the given ones,
+// so per-request or per-tenant policies can be layered over the global policy.
+func WithHandlers(ctx context.Context, pairs ...Pair) context.Context {
+	parent, _ := ctx.Value(handlersKey{}).(*contextHandlers)
+	layered := &contextHandlers{
+		pairs:         make([]Pair, 0, len(pairs)),
+		registrations: make([]registration, 0, len(pairs)),
+	}
+	for _, p := range pairs {
+		layered.pairs = append(layered.pairs, p)
+		layered.registrations = append(layered.registrations, registration{
+			handler: toHandler(p.Target),
+			handle:  handleArtefact(p.Handle),
+		})
+	}
+	if parent != nil {
+		layered.pairs = append(layered.pairs, parent.pairs...)
+		layered.registrations = append(layered.registrations, parent.registrations...)
+	}
+	return context.WithValue(ctx, handlersKey{}, layered)
+}
+
+// HandlersFromContext returns the pairs carried by the context, in the order they are consulted.
+func HandlersFromContext(ctx context.Context) []Pair {
+	if handlers, found := ctx.Value(handlersKey{}).(*contextHandlers); found {
+		return handlers.pairs
+	}
+	return nil
+}
+
+// WithMetadata returns a copy of the context carrying the metadata,
+// which is attached to the events recovered in the context
+// by GuardContext, Dispatch and the middleware of nicehttp.
+// Metadata of the parent context is kept, unless overridden by the same key.
+// Keys attached by the recovery point itself take precedence.
+func WithMetadata(ctx context.Context, metadata map[string]string) context.Context {
+	parent := MetadataFromContext(ctx)
+	merged := make(map[string]string, len(parent)+len(metadata))
+	for k, v := range parent {
+		merged[k] = v
+	}
+	for k, v := range metadata {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, metadataKey{}, merged)
+}
+
+// MetadataFromContext returns the metadata carried by the context. It shall not be modified.
+func MetadataFromContext(ctx context.Context) map[string]string {
+	metadata, _ := ctx.Value(metadataKey{}).(map[string]string)
+	return metadata
+}
+
+func contextRegistrations(ctx context.Context) []registration {
+	if handlers, found := ctx.Value(handlersKey{}).(*contextHandlers); found {
+		return handlers.registrations
+	}
+	return nil
+}
+
+// GuardContext works as Guard, consulting the handlers carried by the context
+// ahead of the globally registered ones.
+// It shall be deferred directly.
+func GuardContext(ctx context.Context) {
+	if RecoveryDisabled {
+		return
+	}
+	if artefact := recover(); artefact != nil {
+		Fallthrough(recovered(ctx, newEvent(artefact), "Guard"))
+	}
+}
+
+// Dispatch the recovered artefact to the handlers carried by the context,
+// then to the globally registered handlers and reporters.
+// It is the building block for recovery points outside this package, e.g. in frameworks:
+//
+//	defer func() {
+//		if artefact := recover(); artefact != nil {
+//			event := nice.Dispatch(ctx, artefact)
+//			nice.Fallthrough(event)
+//			respondWithError()
+//		}
+//	}()
+//
+// It shall be called by the deferred function which recovered,
+// for the stack of the event to start at the panic site.
+func Dispatch(ctx context.Context, artefact any) PanicEvent {
+	return recovered(ctx, newEvent(artefact), "Dispatch")
+}
+
+// Fallthrough panics again with the artefact of the event
+// if it has not been handled, or in dry-run mode.
+func Fallthrough(event PanicEvent) {
+	if !event.Handled {
+		panic(event.Artefact)
+	}
+	reraise(event.Artefact)
+}
+
+// recovered dispatches the event and observes it as unhandled if no handler matched.
+func recovered(ctx context.Context, event PanicEvent, recovery string) PanicEvent {
+	event.ctx = ctx
+	if metadata := MetadataFromContext(ctx); len(metadata) > 0 {
+		merged := make(map[string]string, len(metadata)+len(event.Metadata))
+		for k, v := range metadata {
+			merged[k] = v
+		}
+		for k, v := range event.Metadata {
+			merged[k] = v
+		}
+		event.Metadata = merged
+	}
+	if traceID, spanID, ok := traceMetadata(ctx); ok {
+		event.Metadata = withMetadata(event.Metadata, "trace_id", traceID)
+		event.Metadata["span_id"] = spanID
+	}
+	event = dispatchWith(event, contextRegistrations(ctx))
+	if !event.Handled {
+		unhandled(event, recovery)
+	}
+	return event
+}
+
+// recoverWith dispatches the artefact recovered by the caller with the metadata, as recovered.
+// It shall be called from within the deferred function which recovered, for the stack of the panic.
+func recoverWith(ctx context.Context, artefact any, metadata map[string]string, recovery string) PanicEvent {
+	event := newEvent(artefact)
+	event.Metadata = metadata
+	return recovered(ctx, stacked(event), recovery)
+}
@@ -0,0 +1,68 @@
+package nice
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuardContext(t *testing.T) {
+	t.Run("context handlers ahead of registered", func(t *testing.T) {
+		cleanRegistry(t)
+		var executed []string
+		Register(reflect.TypeFor[string](), func(any) { executed = append(executed, "registered") })
+		ctx := WithHandlers(context.Background(),
+			On(reflect.TypeFor[string](), func(any) { executed = append(executed, "context") }),
+		)
+
+		func() {
+			defer GuardContext(ctx)
+			panic("context")
+		}()
+		func() {
+			defer GuardContext(context.Background())
+			panic("registered")
+		}()
+
+		assert.Equal(t, []string{"context", "registered"}, executed)
+	})
+
+	t.Run("fall back to registered", func(t *testing.T) {
+		cleanRegistry(t)
+		var executed []string
+		Register(reflect.TypeFor[string](), func(any) { executed = append(executed, "registered") })
+		ctx := WithHandlers(context.Background(), On(reflect.TypeFor[int](), func(any) {}))
+
+		func() {
+			defer GuardContext(ctx)
+			panic("fallback")
+		}()
+
+		assert.Equal(t, []string{"registered"}, executed)
+	})
+
+	t.Run("fallthrough", func(t *testing.T) {
+		cleanRegistry(t)
+		ctx := WithHandlers(context.Background(), On(reflect.TypeFor[int](), func(any) {}))
+
+		assert.Panics(t, func() {
+			defer GuardContext(ctx)
+			panic("unhandled")
+		})
+	})
+}
+
+func TestWithMetadata(t *testing.T) {
+	cleanRegistry(t)
+	reporter := &mockReporter{}
+	AddReporter(reporter)
+	ctx := WithMetadata(context.Background(), map[string]string{"tenant": "a", "tier": "gold"})
+	ctx = WithMetadata(ctx, map[string]string{"tenant": "b"})
+
+	assert.Equal(t, map[string]string{"tenant": "b", "tier": "gold"}, MetadataFromContext(ctx))
+	event := Dispatch(ctx, "message")
+	assert.Equal(t, map[string]string{"tenant": "b", "tier": "gold"}, event.Metadata)
+	assert.Equal(t, event.Metadata, reporter.events[0].Metadata)
+}
@@ -0,0 +1,149 @@
+package nice
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrNoCrash is returned by ParseCrash when the input has no Go panic output.
+var ErrNoCrash = errors.New("no Go panic or fatal error found")
+
+// maxCrashLine is the longest line ParseCrash accepts.
+const maxCrashLine = 1 << 20
+
+// CrashError is the artefact of a PanicEvent parsed from crash output.
+// Register `nice.Type[*nice.CrashError]()` to handle crashes of other processes.
+type CrashError struct {
+	// Message printed after "panic: " or "fatal error: ".
+	Message string
+	// Fatal tells whether it was a fatal runtime error, which cannot be recovered.
+	Fatal bool
+}
+
+func (e *CrashError) Error() string {
+	return e.Message
+}
+
+// ParseCrash parses the standard Go panic output, as printed to stderr of a crashed process,
+// into a PanicEvent with a *CrashError artefact.
+// Lines before the panic message, e.g. log output, are skipped.
+// The stack of the panicking goroutine is parsed.
+// The goroutine ID and state, the creator of the goroutine,
+// and any panic raised while recovering are recorded in the event metadata.
+func ParseCrash(r io.Reader) (PanicEvent, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxCrashLine)
+
+	var (
+		event    PanicEvent
+		crash    *CrashError
+		repanics []string
+	)
+
+	// Message
+	for crash == nil && scanner.Scan() {
+		line := scanner.Text()
+		if msg, found := strings.CutPrefix(line, "panic: "); found {
+			crash = &CrashError{Message: trimRecovered(msg)}
+		} else if msg, found := strings.CutPrefix(line, "fatal error: "); found {
+			crash = &CrashError{Message: msg, Fatal: true}
+		}
+	}
+	if crash == nil {
+		if err := scanner.Err(); err != nil {
+			return event, err
+		}
+		return event, ErrNoCrash
+	}
+	event.Artefact = crash
+	event.Metadata = make(map[string]string)
+
+	// Nested panics and signal details, until the goroutine header
+	for scanner.Scan() {
+		line := scanner.Text()
+		if header, found := strings.CutPrefix(line, "goroutine "); found {
+			id, _, _ := strings.Cut(header, " ")
+			event.Metadata["goroutine"] = id
+			if start, end := strings.Index(header, "["), strings.LastIndex(header, "]"); start >= 0 && end > start {
+				event.Metadata["goroutine_state"] = header[start+1 : end]
+			}
+			break
+		}
+		if msg, found := strings.CutPrefix(strings.TrimLeft(line, "\t "), "panic: "); found {
+			repanics = append(repanics, trimRecovered(msg))
+		} else if strings.HasPrefix(line, "[signal ") {
+			event.Metadata["signal"] = strings.Trim(line, "[]")
+		}
+	}
+	if len(repanics) > 0 {
+		event.Metadata["repanic"] = strings.Join(repanics, "; ")
+	}
+
+	// Stack of the panicking goroutine, until the blank line after it
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "...") {
+			// ...additional frames elided...
+			continue
+		}
+		if creator, found := strings.CutPrefix(line, "created by "); found {
+			event.Metadata["created_by"] = creator
+			scanner.Scan() // Location of the go statement
+			continue
+		}
+		if !scanner.Scan() {
+			break
+		}
+		file, lineNo, err := parseFrameLocation(scanner.Text())
+		if err != nil {
+			return event, fmt.Errorf("parse frame of %s: %w", line, err)
+		}
+		event.Stack = append(event.Stack, Frame{
+			Function: parseFrameFunction(line),
+			File:     file,
+			Line:     lineNo,
+		})
+	}
+
+	return event, scanner.Err()
+}
+
+// trimRecovered strips the annotations of a panic which has been recovered and raised again.
+func trimRecovered(msg string) string {
+	if i := strings.LastIndex(msg, " [recovered"); i >= 0 && strings.HasSuffix(msg, "]") {
+		return msg[:i]
+	}
+	return msg
+}
+
+// parseFrameFunction strips the arguments from e.g. `main.(*T).run(0xc000010000, {0x1, 0x2})`.
+func parseFrameFunction(line string) string {
+	if i := strings.LastIndex(line, "("); i > 0 && strings.HasSuffix(line, ")") {
+		return line[:i]
+	}
+	return line
+}
+
+// parseFrameLocation parses e.g. `	/src/main.go:12 +0x1d`.
+func parseFrameLocation(line string) (file string, lineNo int, err error) {
+	location := strings.TrimSpace(line)
+	if i := strings.LastIndex(location, " +0x"); i >= 0 {
+		location = location[:i]
+	}
+	i := strings.LastIndex(location, ":")
+	if i < 0 {
+		return "", 0, fmt.Errorf("no line number in %q", line)
+	}
+	lineNo, err = strconv.Atoi(location[i+1:])
+	if err != nil {
+		return "", 0, fmt.Errorf("line number in %q: %w", line, err)
+	}
+	return location[:i], lineNo, nil
+}
@@ -0,0 +1,93 @@
+package nice_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCrash(t *testing.T) {
+	t.Run("panic in goroutine", func(t *testing.T) {
+		output := `2024/01/02 15:04:05 starting worker
+panic: boom
+
+goroutine 6 [running]:
+main.(*T).run(...)
+	/src/main.go:7
+main.main.func2()
+	/src/main.go:12 +0x46
+created by main.main in goroutine 1
+	/src/main.go:12 +0x88
+`
+		event, err := nice.ParseCrash(strings.NewReader(output))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &nice.CrashError{Message: "boom"}, event.Artefact)
+		assert.Equal(t, []nice.Frame{
+			{Function: "main.(*T).run", File: "/src/main.go", Line: 7},
+			{Function: "main.main.func2", File: "/src/main.go", Line: 12},
+		}, event.Stack)
+		assert.Equal(t, map[string]string{
+			"goroutine":       "6",
+			"goroutine_state": "running",
+			"created_by":      "main.main in goroutine 1",
+		}, event.Metadata)
+	})
+
+	t.Run("repanic", func(t *testing.T) {
+		output := `panic: first [recovered]
+	panic: second
+
+goroutine 1 [running]:
+main.main.func1()
+	/src/main.go:2 +0x25
+panic({0x5181c8?, 0x485f38?})
+	/usr/local/go/src/runtime/panic.go:859 +0x125
+main.main()
+	/src/main.go:2 +0x3e
+`
+		event, err := nice.ParseCrash(strings.NewReader(output))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "first", event.Message())
+		assert.Equal(t, "second", event.Metadata["repanic"])
+		assert.Len(t, event.Stack, 3)
+	})
+
+	t.Run("fatal error", func(t *testing.T) {
+		output := `fatal error: concurrent map writes
+
+goroutine 19 [running]:
+main.write(...)
+	/src/main.go:9 +0x2b
+`
+		event, err := nice.ParseCrash(strings.NewReader(output))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &nice.CrashError{Message: "concurrent map writes", Fatal: true}, event.Artefact)
+		assert.Equal(t, "19", event.Metadata["goroutine"])
+	})
+
+	t.Run("signal", func(t *testing.T) {
+		output := `panic: runtime error: invalid memory address or nil pointer dereference
+[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x4553c2]
+
+goroutine 1 [running]:
+main.main()
+	/src/main.go:5 +0x2
+`
+		event, err := nice.ParseCrash(strings.NewReader(output))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "runtime error: invalid memory address or nil pointer dereference", event.Message())
+		assert.Equal(t, "signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x4553c2", event.Metadata["signal"])
+	})
+
+	t.Run("no crash", func(t *testing.T) {
+		_, err := nice.ParseCrash(strings.NewReader("exit status 1\n"))
+
+		assert.ErrorIs(t, err, nice.ErrNoCrash)
+	})
+}
@@ -0,0 +1,86 @@
+package nice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime/debug"
+	"strconv"
+)
+
+// EnvCrashMonitor marks the process run as the crash monitor by InstallCrashHandler.
+const EnvCrashMonitor = "NICE_CRASH_MONITOR"
+
+// InstallCrashHandler reports the crash of the process to the reporter, even of a panic on a goroutine
+// no recovery point protects, or of a fatal runtime error, before the process dies.
+// The crash output is written by debug.SetCrashOutput to a monitor process,
+// which parses it with ParseCrash and reports the event, with a *CrashError artefact,
+// then flushes the reporter if it is a Flusher.
+// The process ID of the crashed process is recorded as "pid" in the event metadata.
+//
+// The monitor is the executable run again with the same arguments,
+// in which InstallCrashHandler monitors rather than returns.
+// So it shall be called first in main, before any side effect.
+//
+//	func main() {
+//		if err := nice.InstallCrashHandler(nice.ReporterFunc(nice.FileHandler("/var/log/crashes.jsonl"))); err != nil {
+//			log.Print(err)
+//		}
+//		...
+//	}
+func InstallCrashHandler(reporter Reporter) error {
+	if os.Getenv(EnvCrashMonitor) != "" {
+		monitorCrash(os.Stdin, reporter, os.Getppid())
+		osExit(0)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("nice: crash monitor: %w", err)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("nice: crash monitor: %w", err)
+	}
+	defer w.Close()
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Env = append(os.Environ(), EnvCrashMonitor+"=1")
+	cmd.Stdin = r
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	err = cmd.Start()
+	r.Close()
+	if err != nil {
+		return fmt.Errorf("nice: crash monitor: %w", err)
+	}
+	if err := debug.SetCrashOutput(w, debug.CrashOptions{}); err != nil {
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("nice: crash monitor: %w", err)
+	}
+	// The monitor exits once the pipe closes, when this process exits.
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// monitorCrash reads the crash output of the process until it exits, and reports its crash if any.
+func monitorCrash(r io.Reader, reporter Reporter, pid int) {
+	event, err := ParseCrash(r)
+	if err != nil {
+		// The process exited without crashing.
+		return
+	}
+	event.Time = clockNow()
+	event.Metadata["pid"] = strconv.Itoa(pid)
+	if reporterPanic := runHandle(reporter.Report, event); reporterPanic != nil {
+		logError(fmt.Errorf("crash reporter panicked: %s", Stringify(reporterPanic)))
+	}
+	if f, isFlusher := reporter.(Flusher); isFlusher {
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultFlushTimeout)
+		defer cancel()
+		if err := f.Flush(ctx); err != nil {
+			logError(fmt.Errorf("flush crash reporter: %w", err))
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package nice
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallCrashHandler(t *testing.T) {
+	const envReport = "NICE_TEST_CRASH_REPORT"
+	if path := os.Getenv(envReport); path != "" {
+		// The crashing process, run again as its monitor.
+		if err := InstallCrashHandler(ReporterFunc(FileHandler(path))); err != nil {
+			t.Fatal(err)
+		}
+		done := make(chan struct{})
+		go func() { panic(errors.New("unprotected")) }()
+		<-done
+	}
+
+	path := filepath.Join(t.TempDir(), "crashes.jsonl")
+	cmd := exec.Command(os.Args[0], "-test.run=^TestInstallCrashHandler$")
+	cmd.Env = append(os.Environ(), envReport+"="+path)
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	assert.True(t, errors.As(err, &exitErr), "the process crashes: %v", err)
+	assert.Eventually(t, func() bool {
+		report, _ := os.ReadFile(path)
+		return strings.Contains(string(report), `"message":"unprotected"`)
+	}, 5*time.Second, 10*time.Millisecond, "the monitor reports the crash")
+}
+
+func TestMonitorCrash(t *testing.T) {
+	output := "panic: boom\n\ngoroutine 1 [running]:\nmain.main()\n\t/src/main.go:5 +0x1d\n"
+	var events []PanicEvent
+	reporter := ReporterFunc(func(event PanicEvent) { events = append(events, event) })
+
+	monitorCrash(strings.NewReader(output), reporter, 42)
+	monitorCrash(strings.NewReader("exited normally\n"), reporter, 42)
+
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, &CrashError{Message: "boom"}, events[0].Artefact)
+		assert.Equal(t, "42", events[0].Metadata["pid"])
+		assert.False(t, events[0].Time.IsZero())
+	}
+}
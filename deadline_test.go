@@ -0,0 +1,59 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadline(t *testing.T) {
+	errSlow := errors.New("slow")
+
+	t.Run("Next handler", func(t *testing.T) {
+		cleanRegistry(t)
+		abandoned := make(chan error, 1)
+		var handled []string
+		RegisterEvent(errSlow, func(event PanicEvent) {
+			<-event.Context().Done()
+			abandoned <- event.Context().Err()
+		}, Named("slow"), Deadline(10*time.Millisecond))
+		Register(errSlow, func(any) { handled = append(handled, "fallback") })
+
+		event := Dispatch(context.Background(), errSlow)
+
+		assert.True(t, event.Handled)
+		assert.Equal(t, []string{"fallback"}, handled)
+		assert.Equal(t, "slow", event.Metadata["overrun"])
+		assert.Error(t, <-abandoned, "the abandoned handler's context is done")
+	})
+
+	t.Run("Fallthrough", func(t *testing.T) {
+		cleanRegistry(t)
+		release := make(chan struct{})
+		defer close(release)
+		Register(errSlow, func(any) { <-release }, Deadline(10*time.Millisecond))
+
+		event := Dispatch(context.Background(), errSlow)
+
+		assert.False(t, event.Handled)
+		assert.Equal(t, "registration #0", event.Metadata["overrun"])
+		assert.PanicsWithValue(t, errSlow, func() { Fallthrough(event) })
+	})
+
+	t.Run("In time", func(t *testing.T) {
+		cleanRegistry(t)
+		var deadline bool
+		RegisterEvent(errSlow, func(event PanicEvent) {
+			_, deadline = event.Context().Deadline()
+		}, Deadline(time.Second))
+
+		event := Dispatch(context.Background(), errSlow)
+
+		assert.True(t, event.Handled)
+		assert.True(t, deadline)
+		assert.NotContains(t, event.Metadata, "overrun")
+	})
+}
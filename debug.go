@@ -0,0 +1,77 @@
+package nice
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/antonyho/nice/dispatch"
+)
+
+// debugLogger logs the decisions of every recovery point, when set.
+var debugLogger atomic.Pointer[slog.Logger]
+
+// SetDebug enables the debug mode, logging every recover to the logger at debug level:
+// the artefact type, each target evaluated, why it did or didn't match, and which handler ran.
+// Passing nil disables the debug mode.
+func SetDebug(logger *slog.Logger) {
+	debugLogger.Store(logger)
+}
+
+// tracer receives the evaluation of a target while matching.
+type tracer = dispatch.Tracer
+
+// debugTracer returns the tracer logging to the debug logger, or nil if debug mode is off.
+func debugTracer(logger *slog.Logger, handler string) tracer {
+	if logger == nil {
+		return nil
+	}
+	return func(target string, matched bool, reason string) {
+		logger.LogAttrs(context.Background(), slog.LevelDebug, "nice: target evaluated",
+			slog.String("handler", handler),
+			slog.String("target", target),
+			slog.Bool("matched", matched),
+			slog.String("reason", reason),
+		)
+	}
+}
+
+func debugRecovered(logger *slog.Logger, recovery string, artefact any) {
+	if logger != nil {
+		logger.LogAttrs(context.Background(), slog.LevelDebug, "nice: recovered",
+			slog.String("recovery", recovery),
+			slog.String("type", PanicEvent{Artefact: artefact}.Type()),
+		)
+	}
+}
+
+func debugOutcome(logger *slog.Logger, recovery string, handler string, handled bool) {
+	if logger == nil {
+		return
+	}
+	if handled {
+		logger.LogAttrs(context.Background(), slog.LevelDebug, "nice: handled",
+			slog.String("recovery", recovery),
+			slog.String("handler", handler),
+		)
+		return
+	}
+	logger.LogAttrs(context.Background(), slog.LevelDebug, "nice: fell through",
+		slog.String("recovery", recovery),
+	)
+}
+
+// describeTarget names a target for the debug log and reports.
+func describeTarget(target any) string {
+	return dispatch.Describe(target)
+}
+
+// funcName of the handle func for the debug log.
+func funcName(fn any) string {
+	if f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()); f != nil {
+		return f.Name()
+	}
+	return "unknown"
+}
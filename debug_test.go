@@ -0,0 +1,81 @@
+package nice
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func debugRecords(t *testing.T, output *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		var record map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		delete(record, "time")
+		delete(record, "level")
+		records = append(records, record)
+	}
+	return records
+}
+
+func enableDebug(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var output bytes.Buffer
+	SetDebug(slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { SetDebug(nil) })
+	return &output
+}
+
+func namedHandle(any) {}
+
+func TestSetDebug(t *testing.T) {
+	t.Run("With", func(t *testing.T) {
+		output := enableDebug(t)
+		mockErr := errors.New("mock error")
+
+		func() {
+			defer Tackle(reflect.TypeFor[string](), mockErr).With(namedHandle)
+			panic(mockErr)
+		}()
+
+		assert.Equal(t, []map[string]any{
+			{"msg": "nice: recovered", "recovery": "With", "type": "*errors.errorString"},
+			{"msg": "nice: target evaluated", "handler": "With", "target": "type string",
+				"matched": false, "reason": "artefact is of type *errors.errorString"},
+			{"msg": "nice: target evaluated", "handler": "With", "target": `error "mock error" (*errors.errorString)`,
+				"matched": true, "reason": "artefact is the error"},
+			{"msg": "nice: handled", "recovery": "With", "handler": "github.com/antonyho/nice.namedHandle"},
+		}, debugRecords(t, output))
+	})
+
+	t.Run("registry", func(t *testing.T) {
+		cleanRegistry(t)
+		output := enableDebug(t)
+		Register(TypeName("int"), func(any) {}, Named("ints"))
+		Register(Tackle(), func(any) {})
+
+		func() {
+			defer func() { _ = recover() }()
+			guarded("fallthrough")
+		}()
+
+		assert.Equal(t, []map[string]any{
+			{"msg": "nice: recovered", "recovery": "registry", "type": "string"},
+			{"msg": "nice: target evaluated", "handler": "ints", "target": "type name int",
+				"matched": false, "reason": "matcher did not match"},
+			{"msg": "nice: target evaluated", "handler": "registration #1", "target": "type error",
+				"matched": false, "reason": "artefact is not an error"},
+			{"msg": "nice: fell through", "recovery": "registry"},
+		}, debugRecords(t, output))
+	})
+}
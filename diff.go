@@ -0,0 +1,127 @@
+package nice
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EventDiff is the difference between two events, as returned by DiffEvents.
+type EventDiff struct {
+	// SameFingerprint tells whether the events are of the same panic, by the type and message of the artefact.
+	SameFingerprint bool
+	// Changes of the type, message, handled and severity.
+	Changes []Change
+	// CommonFrames is the number of the outermost frames the stacks have in common.
+	CommonFrames int
+	// Diverged tells whether the stacks differ.
+	Diverged bool
+	// DivergedA and DivergedB are the frames right above the common ones, where the stacks diverge.
+	// One is nil if its stack has no more frames.
+	DivergedA, DivergedB *Frame
+	// Metadata and Tags added, removed or changed, by key.
+	Metadata []Change
+	Tags     []Change
+}
+
+// Change of a field from A to B. A missing one is empty.
+type Change struct {
+	Field string
+	A, B  string
+}
+
+// Equal tells whether the events are the same but for their time.
+func (d EventDiff) Equal() bool {
+	return d.SameFingerprint && len(d.Changes) == 0 && !d.Diverged && len(d.Metadata) == 0 && len(d.Tags) == 0
+}
+
+// DiffEvents compares the events for triage, e.g. of the events collected from several instances.
+//
+//	fmt.Print(nice.DiffEvents(yesterday, today))
+func DiffEvents(a, b PanicEvent) EventDiff {
+	d := EventDiff{SameFingerprint: a.Type() == b.Type() && a.Message() == b.Message()}
+	d.Changes = appendChange(d.Changes, "type", a.Type(), b.Type())
+	d.Changes = appendChange(d.Changes, "message", a.Message(), b.Message())
+	d.Changes = appendChange(d.Changes, "handled", fmt.Sprint(a.Handled), fmt.Sprint(b.Handled))
+	d.Changes = appendChange(d.Changes, "severity", a.Severity.String(), b.Severity.String())
+
+	// Stacks start at the panic site, so they are compared from the outermost frame.
+	for d.CommonFrames < min(len(a.Stack), len(b.Stack)) &&
+		a.Stack[len(a.Stack)-1-d.CommonFrames] == b.Stack[len(b.Stack)-1-d.CommonFrames] {
+		d.CommonFrames++
+	}
+	d.Diverged = d.CommonFrames < max(len(a.Stack), len(b.Stack))
+	if d.Diverged {
+		d.DivergedA = divergingFrame(a.Stack, d.CommonFrames)
+		d.DivergedB = divergingFrame(b.Stack, d.CommonFrames)
+	}
+
+	d.Metadata = diffMaps(a.Metadata, b.Metadata)
+	d.Tags = diffMaps(a.Tags, b.Tags)
+	return d
+}
+
+// String renders the diff one difference per line.
+func (d EventDiff) String() string {
+	var w strings.Builder
+	if d.SameFingerprint {
+		w.WriteString("same panic\n")
+	} else {
+		w.WriteString("different panics\n")
+	}
+	for _, c := range d.Changes {
+		fmt.Fprintf(&w, "  %s: %s -> %s\n", c.Field, c.A, c.B)
+	}
+	if d.Diverged {
+		fmt.Fprintf(&w, "  stacks diverge after %d common frames:\n    - %s\n    + %s\n",
+			d.CommonFrames, describeFrame(d.DivergedA), describeFrame(d.DivergedB))
+	}
+	for _, c := range d.Metadata {
+		fmt.Fprintf(&w, "  %s\n", c.describe("metadata"))
+	}
+	for _, c := range d.Tags {
+		fmt.Fprintf(&w, "  %s\n", c.describe("tag"))
+	}
+	return w.String()
+}
+
+func (c Change) describe(kind string) string {
+	switch {
+	case c.A == "":
+		return fmt.Sprintf("%s %s added: %s", kind, c.Field, c.B)
+	case c.B == "":
+		return fmt.Sprintf("%s %s removed: %s", kind, c.Field, c.A)
+	default:
+		return fmt.Sprintf("%s %s: %s -> %s", kind, c.Field, c.A, c.B)
+	}
+}
+
+// divergingFrame returns the frame right above the common ones, or nil if there is none.
+func divergingFrame(stack []Frame, common int) *Frame {
+	if common == len(stack) {
+		return nil
+	}
+	return &stack[len(stack)-1-common]
+}
+
+func describeFrame(f *Frame) string {
+	if f == nil {
+		return "(none)"
+	}
+	return fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line)
+}
+
+func appendChange(changes []Change, field, a, b string) []Change {
+	if a == b {
+		return changes
+	}
+	return append(changes, Change{Field: field, A: a, B: b})
+}
+
+// diffMaps returns the changes of the keys in order.
+func diffMaps(a, b map[string]string) []Change {
+	var changes []Change
+	for _, k := range sortedKeys(mergeTags(a, b)) {
+		changes = appendChange(changes, k, a[k], b[k])
+	}
+	return changes
+}
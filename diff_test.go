@@ -0,0 +1,75 @@
+package nice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffEvents(t *testing.T) {
+	a := PanicEvent{
+		Artefact: errors.New("timeout"),
+		Handled:  true,
+		Stack: []Frame{
+			{Function: "main.fetch", File: "/src/fetch.go", Line: 12},
+			{Function: "main.run", File: "/src/main.go", Line: 9},
+			{Function: "main.main", File: "/src/main.go", Line: 3},
+		},
+		Metadata: map[string]string{"request": "42", "host": "a"},
+		Tags:     map[string]string{"team": "payments"},
+	}
+
+	t.Run("Equal", func(t *testing.T) {
+		diff := DiffEvents(a, a)
+		assert.True(t, diff.Equal())
+		assert.Equal(t, 3, diff.CommonFrames)
+		assert.Equal(t, "same panic\n", diff.String())
+	})
+
+	t.Run("Different", func(t *testing.T) {
+		b := a
+		b.Artefact = errors.New("refused")
+		b.Handled = false
+		b.Severity = SeverityCritical
+		b.Stack = []Frame{
+			{Function: "main.dial", File: "/src/dial.go", Line: 5},
+			{Function: "main.main", File: "/src/main.go", Line: 3},
+		}
+		b.Metadata = map[string]string{"request": "43", "zone": "eu"}
+		b.Tags = nil
+
+		diff := DiffEvents(a, b)
+
+		assert.False(t, diff.Equal())
+		assert.False(t, diff.SameFingerprint)
+		assert.Equal(t, 1, diff.CommonFrames)
+		assert.Equal(t, &a.Stack[1], diff.DivergedA)
+		assert.Equal(t, &b.Stack[0], diff.DivergedB)
+		assert.Equal(t, "different panics\n"+
+			"  message: timeout -> refused\n"+
+			"  handled: true -> false\n"+
+			"  severity: default -> critical\n"+
+			"  stacks diverge after 1 common frames:\n"+
+			"    - main.run /src/main.go:9\n"+
+			"    + main.dial /src/dial.go:5\n"+
+			"  metadata host removed: a\n"+
+			"  metadata request: 42 -> 43\n"+
+			"  metadata zone added: eu\n"+
+			"  tag team removed: payments\n",
+			diff.String())
+	})
+
+	t.Run("Deeper stack", func(t *testing.T) {
+		b := a
+		b.Stack = a.Stack[1:]
+
+		diff := DiffEvents(a, b)
+
+		assert.True(t, diff.SameFingerprint)
+		assert.True(t, diff.Diverged)
+		assert.Equal(t, 2, diff.CommonFrames)
+		assert.Equal(t, &a.Stack[0], diff.DivergedA)
+		assert.Nil(t, diff.DivergedB)
+	})
+}
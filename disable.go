@@ -0,0 +1,12 @@
+//go:build !nicedisable
+
+package nice
+
+// RecoveryDisabled tells whether the recovery points are bypassed by the nicedisable build tag.
+//
+// Built with the tag, no recovery point of nice recovers, neither Tackle, Guard, Route nor the protected calls,
+// so every panic crashes right away with the full native stack, e.g. to run tests and local builds
+// in fail-fast mode while production keeps recovery:
+//
+//	go test -tags nicedisable ./...
+const RecoveryDisabled = false
@@ -0,0 +1,6 @@
+//go:build nicedisable
+
+package nice
+
+// RecoveryDisabled tells whether the recovery points are bypassed by the nicedisable build tag.
+const RecoveryDisabled = true
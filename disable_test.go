@@ -0,0 +1,33 @@
+//go:build nicedisable
+
+package nice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoveryDisabled(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	assert.PanicsWithValue(t, errBoom, func() {
+		defer Tackle(errBoom).With(func(any) { t.Error("unexpected handling") })
+		panic(errBoom)
+	})
+	assert.PanicsWithValue(t, errBoom, func() {
+		defer Route(On(errBoom, func(any) { t.Error("unexpected handling") })).Guard()
+		panic(errBoom)
+	})
+	assert.PanicsWithValue(t, errBoom, func() {
+		_ = Protect(func() { panic(errBoom) })
+	})
+
+	finally := false
+	assert.Panics(t, func() {
+		defer Tackle(errBoom).Finally(func() { finally = true }).With()
+		panic(errBoom)
+	})
+	assert.True(t, finally, "Finally still runs")
+}
@@ -0,0 +1,203 @@
+/*
+Package dispatch is the engine matching panic artefacts against targets, which nice is built on.
+Framework authors can embed it in their own recovery points, while nice stays simple.
+*/
+package dispatch
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Matcher is a target with custom matching logic.
+type Matcher interface {
+	Match(artefact any) bool
+}
+
+// Tracer receives the evaluation of every target while matching, described by Describe.
+type Tracer func(target string, matched bool, reason string)
+
+// IndexThreshold is the number of error targets from which Compile indexes them.
+const IndexThreshold = 8
+
+var typeOfError = reflect.TypeFor[error]()
+
+// Targets are compiled for matching, by kind.
+type Targets struct {
+	// Types match artefacts of the exact type. The error interface type matches all errors.
+	Types []reflect.Type
+	// Errors match artefacts equal to them.
+	Errors []error
+	// Index of Errors, built by Compile if they are many and all Hashable.
+	// Errors are scanned if it is nil.
+	Index map[error]struct{}
+	// Matchers match artefacts by their own logic.
+	Matchers []Matcher
+}
+
+// Compile the targets: Matchers, errors and reflect.Types.
+// Other targets are ignored.
+func Compile(targets ...any) Targets {
+	compiled := Targets{
+		Types:  make([]reflect.Type, 0),
+		Errors: make([]error, 0),
+	}
+	for _, t := range targets {
+		if matcher, matched := t.(Matcher); matched {
+			compiled.Matchers = append(compiled.Matchers, matcher)
+			continue
+		}
+		if err, matched := t.(error); matched {
+			compiled.Errors = append(compiled.Errors, err)
+			continue
+		}
+		if artefactType, matched := t.(reflect.Type); matched {
+			compiled.Types = append(compiled.Types, artefactType)
+		}
+	}
+	compiled.Index = index(compiled.Errors)
+	return compiled
+}
+
+// index returns the index of the errors if they are many and all Hashable.
+func index(errs []error) map[error]struct{} {
+	if len(errs) < IndexThreshold {
+		return nil
+	}
+	index := make(map[error]struct{}, len(errs))
+	for _, e := range errs {
+		if !Hashable(e) {
+			return nil
+		}
+		index[e] = struct{}{}
+	}
+	return index
+}
+
+// Match the artefact against the targets in order: types, errors, then matchers,
+// reporting every evaluated target to the tracer if not nil.
+func (t Targets) Match(artefact any, trace Tracer) bool {
+	typeOfArtefact := reflect.TypeOf(artefact)
+	asserted, isError := artefact.(error)
+
+	for _, target := range t.Types {
+		// Handle general error registered, or the exact type of the artefact which is not an error
+		matched := (!isError && target == typeOfArtefact) || (isError && target == typeOfError)
+		if trace != nil {
+			trace(Describe(target), matched, typeMatchReason(target, typeOfArtefact, matched))
+		}
+		if matched {
+			return true
+		}
+	}
+	// Errors which are not Hashable equal no target, as comparing them would panic.
+	equatable := isError && Hashable(asserted)
+	if t.Index != nil && trace == nil {
+		if equatable {
+			if _, matched := t.Index[asserted]; matched {
+				return true
+			}
+		}
+	} else {
+		for _, e := range t.Errors {
+			// Handle specific error registered
+			matched := equatable && asserted == e
+			if trace != nil {
+				trace(Describe(e), matched, errorMatchReason(isError, matched))
+			}
+			if matched {
+				return true
+			}
+		}
+	}
+	for _, m := range t.Matchers {
+		matched := m.Match(artefact)
+		if trace != nil {
+			trace(Describe(m), matched, matcherReason(matched))
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Hashable tells whether the value can be a map key without panicking.
+// A value of a comparable type is not if it holds a slice, a map or a func in an interface field,
+// e.g. struct{ V any }{V: []int{1}}.
+func Hashable(v any) bool {
+	return hashable(reflect.ValueOf(v))
+}
+
+func hashable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Slice, reflect.Map, reflect.Func:
+		return false
+	case reflect.Interface:
+		return v.IsNil() || hashable(v.Elem())
+	case reflect.Array:
+		for i := range v.Len() {
+			if !hashable(v.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if !hashable(v.Field(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Describe names a target for the debug log and reports.
+func Describe(target any) string {
+	switch t := target.(type) {
+	case reflect.Type:
+		return "type " + t.String()
+	case fmt.Stringer:
+		return t.String()
+	case error:
+		return fmt.Sprintf("error %q (%T)", t.Error(), t)
+	default:
+		return fmt.Sprintf("%T", t)
+	}
+}
+
+func typeMatchReason(target, artefact reflect.Type, matched bool) string {
+	switch {
+	case matched && target == typeOfError:
+		return "artefact is an error"
+	case matched:
+		return "artefact is of the type"
+	case target == typeOfError:
+		return "artefact is not an error"
+	case target == artefact:
+		return "errors are matched by value or as error"
+	case artefact == nil:
+		return "artefact has no type"
+	default:
+		return "artefact is of type " + artefact.String()
+	}
+}
+
+func errorMatchReason(isError, matched bool) string {
+	switch {
+	case matched:
+		return "artefact is the error"
+	case !isError:
+		return "artefact is not an error"
+	default:
+		return "artefact is a different error"
+	}
+}
+
+func matcherReason(matched bool) string {
+	if matched {
+		return "matcher matched"
+	}
+	return "matcher did not match"
+}
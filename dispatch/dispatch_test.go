@@ -0,0 +1,143 @@
+package dispatch
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type uncomparableError []string
+
+func (e uncomparableError) Error() string { return "uncomparable" }
+
+// errV is of a comparable type, but not Hashable while holding a slice.
+type errV struct{ V any }
+
+func (errV) Error() string { return "errV" }
+
+type oddMatcher struct{}
+
+func (oddMatcher) Match(artefact any) bool {
+	i, ok := artefact.(int)
+	return ok && i%2 == 1
+}
+
+func TestCompile(t *testing.T) {
+	errTarget := errors.New("target")
+	compiled := Compile(reflect.TypeFor[string](), errTarget, oddMatcher{}, 42)
+
+	assert.Equal(t, []reflect.Type{reflect.TypeFor[string]()}, compiled.Types)
+	assert.Equal(t, []error{errTarget}, compiled.Errors)
+	assert.Equal(t, []Matcher{oddMatcher{}}, compiled.Matchers)
+	assert.Nil(t, compiled.Index)
+
+	t.Run("Many errors are indexed", func(t *testing.T) {
+		targets := make([]any, IndexThreshold)
+		for i := range targets {
+			targets[i] = fmt.Errorf("error %d", i)
+		}
+		assert.Len(t, Compile(targets...).Index, IndexThreshold)
+	})
+
+	t.Run("Errors not hashable are not indexed", func(t *testing.T) {
+		targets := make([]any, IndexThreshold)
+		for i := range targets {
+			targets[i] = fmt.Errorf("error %d", i)
+		}
+		targets[0] = errV{V: []int{1}}
+		var compiled Targets
+		assert.NotPanics(t, func() { compiled = Compile(targets...) })
+		assert.Nil(t, compiled.Index)
+		assert.NotPanics(t, func() { assert.False(t, compiled.Match(errV{V: []int{1}}, nil)) })
+		assert.True(t, compiled.Match(targets[1], nil))
+	})
+
+	t.Run("Uncomparable errors are not indexed", func(t *testing.T) {
+		targets := make([]any, IndexThreshold)
+		for i := range targets {
+			targets[i] = uncomparableError{}
+		}
+		assert.Nil(t, Compile(targets...).Index)
+	})
+}
+
+func TestTargetsMatch(t *testing.T) {
+	errTarget := errors.New("target")
+	targets := Compile(reflect.TypeFor[string](), errTarget, oddMatcher{})
+
+	assert.True(t, targets.Match("artefact", nil))
+	assert.True(t, targets.Match(errTarget, nil))
+	assert.True(t, targets.Match(3, nil))
+	assert.False(t, targets.Match(4, nil))
+	assert.False(t, targets.Match(errors.New("target"), nil))
+	assert.True(t, Compile(reflect.TypeFor[error]()).Match(errors.New("any"), nil))
+	assert.False(t, Compile(reflect.TypeFor[*fs.PathError]()).Match(&fs.PathError{Op: "open"}, nil), "errors match by value or as error, not by their type")
+
+	t.Run("Trace", func(t *testing.T) {
+		var reasons []string
+		matched := targets.Match(4, func(target string, matched bool, reason string) {
+			reasons = append(reasons, fmt.Sprintf("%s %t %s", target, matched, reason))
+		})
+		assert.False(t, matched)
+		assert.Equal(t, []string{
+			"type string false artefact is of type int",
+			`error "target" (*errors.errorString) false artefact is not an error`,
+			"dispatch.oddMatcher false matcher did not match",
+		}, reasons)
+	})
+
+	t.Run("Index", func(t *testing.T) {
+		targets := make([]any, IndexThreshold)
+		for i := range targets {
+			targets[i] = fmt.Errorf("error %d", i)
+		}
+		compiled := Compile(targets...)
+		assert.True(t, compiled.Match(targets[IndexThreshold-1], nil))
+		assert.False(t, compiled.Match(uncomparableError{}, nil))
+		assert.NotPanics(t, func() {
+			assert.False(t, compiled.Match(errV{V: []int{1}}, nil), "errors not hashable are not looked up")
+		})
+		assert.False(t, compiled.Match(errors.New("error 0"), nil))
+	})
+}
+
+func TestEngine(t *testing.T) {
+	errNotFound := errors.New("not found")
+	var engine Engine[int]
+	engine.Add(404, errNotFound)
+	engine.Add(400, reflect.TypeFor[string]())
+	engine.Add(500, reflect.TypeFor[error]())
+
+	status, matched := engine.Match(errNotFound)
+	assert.True(t, matched)
+	assert.Equal(t, 404, status)
+
+	status, matched = engine.Match("bad request")
+	assert.True(t, matched)
+	assert.Equal(t, 400, status)
+
+	status, matched = engine.Match(errors.New("other"))
+	assert.True(t, matched)
+	assert.Equal(t, 500, status)
+
+	status, matched = engine.Match(42)
+	assert.False(t, matched)
+	assert.Zero(t, status)
+	assert.Len(t, engine.Rules(), 3)
+}
+
+func TestHashable(t *testing.T) {
+	assert.True(t, Hashable(nil))
+	assert.True(t, Hashable(errors.New("pointer")))
+	assert.True(t, Hashable(errV{V: 1}))
+	assert.True(t, Hashable([2]any{1, "a"}))
+	assert.False(t, Hashable(errV{V: []int{1}}))
+	assert.False(t, Hashable([1]any{map[string]int{}}))
+	assert.False(t, Hashable(uncomparableError{}))
+	assert.False(t, Hashable(func() {}))
+	assert.False(t, Hashable(struct{ v any }{v: []int{1}}), "unexported fields are walked too")
+}
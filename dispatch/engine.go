@@ -0,0 +1,52 @@
+package dispatch
+
+// Engine routes artefacts to the handles of the first rule matching them, in the order of the rules.
+// H is the handle of the recovery point, e.g. func(any) or an HTTP status.
+// It is not safe to add rules while matching concurrently.
+//
+//	var engine dispatch.Engine[int]
+//	engine.Add(http.StatusNotFound, ErrNotFound)
+//	engine.Add(http.StatusBadRequest, ErrInvalid)
+//	...
+//	if status, matched := engine.Match(artefact); matched {
+//		w.WriteHeader(status)
+//	}
+type Engine[H any] struct {
+	rules []Rule[H]
+}
+
+// Rule of an Engine.
+type Rule[H any] struct {
+	Targets Targets
+	Handle  H
+}
+
+// Add a rule of the handle for the targets, accepted as by Compile.
+func (e *Engine[H]) Add(handle H, targets ...any) {
+	e.AddRule(Rule[H]{Targets: Compile(targets...), Handle: handle})
+}
+
+// AddRule adds a rule of compiled targets.
+func (e *Engine[H]) AddRule(r Rule[H]) {
+	e.rules = append(e.rules, r)
+}
+
+// Rules returns the rules in order.
+func (e *Engine[H]) Rules() []Rule[H] {
+	return e.rules
+}
+
+// Match returns the handle of the first rule matching the artefact.
+func (e *Engine[H]) Match(artefact any) (handle H, matched bool) {
+	return e.Trace(artefact, nil)
+}
+
+// Trace works as Match, reporting every evaluated target to the tracer.
+func (e *Engine[H]) Trace(artefact any, trace Tracer) (handle H, matched bool) {
+	for _, r := range e.rules {
+		if r.Targets.Match(artefact, trace) {
+			return r.Handle, true
+		}
+	}
+	return handle, false
+}
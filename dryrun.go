@@ -0,0 +1,27 @@
+package nice
+
+import (
+	"sync/atomic"
+)
+
+var dryRun atomic.Bool
+
+// SetDryRun toggles the dry-run mode, in which handlers and reporters are given the artefact
+// for observation, but the panic is raised again afterwards as if it was never recovered.
+// Teams can roll out nice for telemetry first, and enable the recovery later with confidence.
+func SetDryRun(enabled bool) {
+	dryRun.Store(enabled)
+}
+
+// reraise panics with the artefact again in dry-run mode, or in Development mode.
+// It shall be called by the recovery points after handling.
+func reraise(artefact any) {
+	if reraising() {
+		panic(artefact)
+	}
+}
+
+// reraising tells whether reraise panics again.
+func reraising() bool {
+	return dryRun.Load() || Mode(mode.Load()) == Development
+}
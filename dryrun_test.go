@@ -0,0 +1,58 @@
+package nice
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func enableDryRun(t *testing.T) {
+	t.Helper()
+	SetDryRun(true)
+	t.Cleanup(func() { SetDryRun(false) })
+}
+
+func TestSetDryRun(t *testing.T) {
+	t.Run("With", func(t *testing.T) {
+		enableDryRun(t)
+		mockErr := errors.New("observed")
+		var observed any
+
+		reraised := func() (artefact any) {
+			defer func() { artefact = recover() }()
+			defer Tackle(mockErr).With(func(artefact any) { observed = artefact })
+			panic(mockErr)
+		}()
+
+		assert.Equal(t, mockErr, observed, "The handler observes the artefact.")
+		assert.Equal(t, mockErr, reraised, "The panic is raised again.")
+	})
+
+	t.Run("Guard", func(t *testing.T) {
+		cleanRegistry(t)
+		enableDryRun(t)
+		reporter := &mockReporter{}
+		AddReporter(reporter)
+		Register(reflect.TypeFor[string](), func(any) {})
+
+		reraised := func() (artefact any) {
+			defer func() { artefact = recover() }()
+			guarded("observed")
+			return nil
+		}()
+
+		assert.Equal(t, "observed", reraised)
+		if assert.Len(t, reporter.events, 1) {
+			assert.True(t, reporter.events[0].Handled)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		cleanRegistry(t)
+		Register(reflect.TypeFor[string](), func(any) {})
+
+		assert.NotPanics(t, func() { guarded("recovered") })
+	})
+}
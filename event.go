@@ -0,0 +1,269 @@
+package nice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// maxStackDepth is the default number of frames captured for a PanicEvent.
+const maxStackDepth = 64
+
+// PanicEvent describes a tackled panic and where it came from.
+type PanicEvent struct {
+	// Artefact is the value passed to panic.
+	Artefact any
+	// Handled tells whether a handler matched the artefact.
+	Handled bool
+	// Member of a joined error artefact matched by the handler, or nil if the artefact matched itself.
+	Member error
+	// Severity configured for the matched handler.
+	Severity Severity
+	// Time of recovery.
+	Time time.Time
+	// Stack of the panicking goroutine, starting at the panic site.
+	// It is captured only if the matched handler or a reporter declared NeedsStack,
+	// or if no handler matched.
+	Stack []Frame
+	// Metadata carries extra details attached by the recovery point.
+	Metadata map[string]string
+	// Tags are the static tags of the matched registration, see Tags.
+	Tags map[string]string
+	// HandlerPanic is the value the matched handler panicked with, if it did, see OnHandlerPanic.
+	HandlerPanic any
+
+	ctx context.Context
+	// resolved artefact matched by the handler, passed to the handle func of Register.
+	resolved any
+	// sampledOut by Sample.
+	sampledOut bool
+	// local events are recovered in this goroutine, so their stack is captured by stacked.
+	// Events from elsewhere, e.g. parsed by ParseCrash, keep the stack they come with.
+	local bool
+}
+
+// Frame is a single call in the stack of a PanicEvent.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// String formats the frame as in Go's panic output.
+func (f Frame) String() string {
+	return fmt.Sprintf("%s()\n\t%s:%d", f.Function, f.File, f.Line)
+}
+
+// Standard tells whether the frame is of the standard library, by the package path of its function.
+// The import paths of other packages start with a domain.
+func (f Frame) Standard() bool {
+	pkg, _, _ := strings.Cut(f.Function, "/")
+	if !strings.Contains(f.Function, "/") {
+		pkg, _, _ = strings.Cut(f.Function, ".")
+	}
+	return pkg != "main" && !strings.Contains(pkg, ".")
+}
+
+// Context of the recovery point, e.g. of the request recovered by nicehttp.Middleware,
+// for handlers correlating the event with traces.
+// It is context.Background if the recovery point has no context.
+func (e PanicEvent) Context() context.Context {
+	if e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
+}
+
+// SampledOut tells whether the event is sampled out by Sample.
+// The handlers still run, but the logging ones, e.g. of LogHandler, skip it, and so do the reporters.
+func (e PanicEvent) SampledOut() bool {
+	return e.sampledOut
+}
+
+// Message of the artefact.
+// It is formatted by Stringify, so formatting an odd artefact is bounded and never panics.
+func (e PanicEvent) Message() string {
+	return Stringify(e.Artefact)
+}
+
+// Type name of the artefact.
+func (e PanicEvent) Type() string {
+	if e.Artefact == nil {
+		return "nil"
+	}
+	if r, matched := e.Artefact.(Recorded); matched {
+		return r.TypeName
+	}
+	return reflect.TypeOf(e.Artefact).String()
+}
+
+// TypeName of the artefact for humans: the name registered by Describe, or the Type.
+func (e PanicEvent) TypeName() string {
+	if r, matched := e.Artefact.(Recorded); matched && r.Name != "" {
+		return r.Name
+	}
+	if described, found := DescribedType(e.Artefact); found {
+		return described.Name
+	}
+	return e.Type()
+}
+
+// String renders the event as a crash report similar to Go's panic output.
+func (e PanicEvent) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "panic: %s (%s)", e.Message(), e.TypeName())
+	if e.Handled {
+		b.WriteString(" [recovered]")
+	}
+	b.WriteString("\n")
+	for _, k := range sortedKeys(e.Metadata) {
+		fmt.Fprintf(&b, "\t%s=%s\n", k, e.Metadata[k])
+	}
+	for _, k := range sortedKeys(e.Tags) {
+		fmt.Fprintf(&b, "\ttag %s=%s\n", k, e.Tags[k])
+	}
+	if e.HandlerPanic != nil {
+		fmt.Fprintf(&b, "\thandler panicked: %s\n", Stringify(e.HandlerPanic))
+	}
+	if len(e.Stack) > 0 {
+		b.WriteString("\n")
+	}
+	for _, f := range e.Stack {
+		b.WriteString(f.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// eventJSON is the JSON encoding of PanicEvent.
+// The artefact is described by its type and message.
+type eventJSON struct {
+	Type string `json:"type"`
+	// Name of the type, if registered by Describe.
+	Name     string            `json:"name,omitempty"`
+	Message  string            `json:"message"`
+	Handled  bool              `json:"handled"`
+	Severity Severity          `json:"severity"`
+	Time     time.Time         `json:"time"`
+	Stack    []Frame           `json:"stack,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	// HandlerPanic is described by its message.
+	HandlerPanic string `json:"handler_panic,omitempty"`
+}
+
+// MarshalJSON encodes the event with the type and message of the artefact.
+func (e PanicEvent) MarshalJSON() ([]byte, error) {
+	encoded := eventJSON{
+		Type:         e.Type(),
+		Message:      e.Message(),
+		Handled:      e.Handled,
+		Severity:     e.Severity,
+		Time:         e.Time,
+		Stack:        e.Stack,
+		Metadata:     e.Metadata,
+		Tags:         e.Tags,
+		HandlerPanic: handlerPanicMessage(e.HandlerPanic),
+	}
+	if name := e.TypeName(); name != encoded.Type {
+		encoded.Name = name
+	}
+	return json.Marshal(encoded)
+}
+
+func handlerPanicMessage(handlerPanic any) string {
+	if handlerPanic == nil {
+		return ""
+	}
+	return Stringify(handlerPanic)
+}
+
+// UnmarshalJSON decodes the event encoded by MarshalJSON, e.g. from a crash report.
+// The artefact is decoded as Recorded.
+func (e *PanicEvent) UnmarshalJSON(data []byte) error {
+	var decoded eventJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*e = PanicEvent{
+		Artefact: Recorded{TypeName: decoded.Type, Name: decoded.Name, Text: decoded.Message},
+		Handled:  decoded.Handled,
+		Severity: decoded.Severity,
+		Time:     decoded.Time,
+		Stack:    decoded.Stack,
+		Metadata: decoded.Metadata,
+		Tags:     decoded.Tags,
+	}
+	if decoded.HandlerPanic != "" {
+		e.HandlerPanic = decoded.HandlerPanic
+	}
+	return nil
+}
+
+// newEvent creates an event for the artefact recovered by the caller.
+// Its stack is captured later by stacked, while still recovering, and only if needed.
+func newEvent(artefact any) PanicEvent {
+	return PanicEvent{
+		Artefact: artefact,
+		Time:     clockNow(),
+		local:    true,
+	}
+}
+
+// stacked captures the stack of the local event, unless it already has one.
+// It shall be called from within the deferred function which recovered.
+func stacked(event PanicEvent) PanicEvent {
+	if event.Stack == nil && event.local {
+		event.Stack = captureStack(3)
+	}
+	return event
+}
+
+// captureStack returns the stack from the panic site,
+// dropping the frames of runtime's panic machinery and of the recovery point.
+// If the goroutine is not panicking, the stack starts at skip.
+// The frames are limited as configured by SetStack.
+func captureStack(skip int) []Frame {
+	cfg := stackSettings.Load()
+	if cfg.depth == 0 {
+		return nil
+	}
+	pcs := make([]uintptr, cfg.depth+cfg.skip+recoveryFrames)
+	n := runtime.Callers(skip, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	stack := make([]Frame, 0, min(n, cfg.depth))
+	panicking := false
+	skipped := 0
+	for {
+		frame, more := frames.Next()
+		switch {
+		case frame.Function == "runtime.gopanic":
+			// Everything above the panic belongs to the recovery point.
+			stack = stack[:0]
+			panicking = true
+			skipped = 0
+		case panicking && strings.HasPrefix(frame.Function, "runtime."):
+			// runtime.sigpanic, runtime.panicmem, runtime.goPanicIndex etc.
+		case frame.Function == "runtime.goexit":
+		case skipped < cfg.skip:
+			panicking = false
+			skipped++
+		default:
+			panicking = false
+			stack = append(stack, Frame{
+				Function: frame.Function,
+				File:     frame.File,
+				Line:     frame.Line,
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	return stack[:min(len(stack), cfg.depth)]
+}
@@ -0,0 +1,103 @@
+package nice
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func panickingFunc() {
+	panic(errors.New("stack error"))
+}
+
+func TestNewEvent(t *testing.T) {
+	var event PanicEvent
+	func() {
+		defer func() {
+			event = stacked(newEvent(recover()))
+		}()
+		panickingFunc()
+	}()
+
+	assert.Equal(t, "stack error", event.Message())
+	assert.Equal(t, "*errors.errorString", event.Type())
+	if assert.NotEmpty(t, event.Stack) {
+		assert.Equal(t, "github.com/antonyho/nice.panickingFunc", event.Stack[0].Function,
+			"The stack starts at the panic site.")
+		assert.True(t, strings.HasSuffix(event.Stack[0].File, "event_test.go"))
+	}
+	for _, f := range event.Stack {
+		assert.False(t, strings.HasPrefix(f.Function, "runtime."), "No runtime frame: %s", f.Function)
+	}
+}
+
+func TestPanicEventString(t *testing.T) {
+	event := PanicEvent{
+		Artefact: "boom",
+		Handled:  true,
+		Stack:    []Frame{{Function: "main.main", File: "/src/main.go", Line: 7}},
+		Metadata: map[string]string{"path": "/tmp/x"},
+	}
+	expected := "panic: boom (string) [recovered]\n" +
+		"\tpath=/tmp/x\n" +
+		"\n" +
+		"main.main()\n" +
+		"\t/src/main.go:7\n"
+	assert.Equal(t, expected, event.String())
+}
+
+func TestPanicEventMarshalJSON(t *testing.T) {
+	event := PanicEvent{
+		Artefact: errors.New("boom"),
+		Severity: SeverityCritical,
+		Time:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Stack:    []Frame{{Function: "main.main", File: "/src/main.go", Line: 7}},
+	}
+
+	encoded, err := json.Marshal(event)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "*errors.errorString",
+		"message": "boom",
+		"handled": false,
+		"severity": "critical",
+		"time": "2024-01-02T03:04:05Z",
+		"stack": [{"function": "main.main", "file": "/src/main.go", "line": 7}]
+	}`, string(encoded))
+}
+
+func TestPanicEventUnmarshalJSON(t *testing.T) {
+	event := PanicEvent{
+		Artefact: errors.New("boom"),
+		Handled:  true,
+		Severity: SeverityCritical,
+		Time:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Stack:    []Frame{{Function: "main.main", File: "/src/main.go", Line: 7}},
+		Metadata: map[string]string{"request": "42"},
+		Tags:     map[string]string{"team": "payments"},
+	}
+	encoded, err := json.Marshal(event)
+	assert.NoError(t, err)
+
+	var decoded PanicEvent
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+
+	assert.Equal(t, Recorded{TypeName: "*errors.errorString", Text: "boom"}, decoded.Artefact)
+	assert.Equal(t, event.Type(), decoded.Type())
+	assert.Equal(t, event.String(), decoded.String())
+	reencoded, err := json.Marshal(decoded)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(encoded), string(reencoded))
+}
+
+func TestFrameStandard(t *testing.T) {
+	assert.True(t, Frame{Function: "runtime.goPanicIndex"}.Standard())
+	assert.True(t, Frame{Function: "net/http.(*conn).serve"}.Standard())
+	assert.False(t, Frame{Function: "main.main"}.Standard())
+	assert.False(t, Frame{Function: "github.com/acme/app/parser.parse"}.Standard())
+}
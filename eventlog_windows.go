@@ -0,0 +1,74 @@
+package nice
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	advapi32                  = syscall.NewLazyDLL("advapi32.dll")
+	procRegisterEventSourceW  = advapi32.NewProc("RegisterEventSourceW")
+	procReportEventW          = advapi32.NewProc("ReportEventW")
+	procDeregisterEventSource = advapi32.NewProc("DeregisterEventSource")
+)
+
+// Types of the Windows Event Log entries.
+const (
+	eventLogError       = 0x0001
+	eventLogWarning     = 0x0002
+	eventLogInformation = 0x0004
+)
+
+// EventLogID is the event ID of the entries written by EventLogHandler.
+const EventLogID = 1
+
+// EventLogHandler returns an event handle func writing the crash report of the event
+// to the Windows Event Log under the source name, e.g. the name of the Windows service.
+// The returned release func releases the event source.
+// Register it with NeedsStack for the stack in the report.
+func EventLogHandler(source string) (handle func(event PanicEvent), release func() error, err error) {
+	name, err := syscall.UTF16PtrFromString(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	h, _, err := procRegisterEventSourceW.Call(0, uintptr(unsafe.Pointer(name)))
+	if h == 0 {
+		return nil, nil, fmt.Errorf("register event source %q: %w", source, err)
+	}
+
+	handle = func(event PanicEvent) {
+		if event.SampledOut() {
+			return
+		}
+		report, err := syscall.UTF16PtrFromString(event.String())
+		if err != nil {
+			logError(fmt.Errorf("encode event: %w", err))
+			return
+		}
+		reports := []*uint16{report}
+		r, _, err := procReportEventW.Call(h, uintptr(eventLogType(event.Severity)), 0, EventLogID, 0,
+			uintptr(len(reports)), 0, uintptr(unsafe.Pointer(&reports[0])), 0)
+		if r == 0 {
+			logError(fmt.Errorf("report event: %w", err))
+		}
+	}
+	release = func() error {
+		if r, _, err := procDeregisterEventSource.Call(h); r == 0 {
+			return fmt.Errorf("deregister event source %q: %w", source, err)
+		}
+		return nil
+	}
+	return handle, release, nil
+}
+
+func eventLogType(s Severity) uint16 {
+	switch s {
+	case SeverityDebug, SeverityInfo:
+		return eventLogInformation
+	case SeverityWarning:
+		return eventLogWarning
+	default:
+		return eventLogError
+	}
+}
@@ -0,0 +1,24 @@
+package nice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventLogType(t *testing.T) {
+	assert.Equal(t, uint16(eventLogInformation), eventLogType(SeverityInfo))
+	assert.Equal(t, uint16(eventLogWarning), eventLogType(SeverityWarning))
+	assert.Equal(t, uint16(eventLogError), eventLogType(SeverityDefault))
+	assert.Equal(t, uint16(eventLogError), eventLogType(SeverityCritical))
+}
+
+func TestEventLogHandler(t *testing.T) {
+	handle, closeSource, err := EventLogHandler("nice-test")
+	if err != nil {
+		t.Skipf("event log unavailable: %v", err)
+	}
+	defer closeSource()
+
+	assert.NotPanics(t, func() { handle(mockEvent()) })
+}
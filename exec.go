@@ -0,0 +1,92 @@
+package nice
+
+import (
+	"bytes"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Exec runs the command and waits for it to exit, like cmd.Run.
+// The standard error of the command is still written to cmd.Stderr,
+// while being watched for Go panic output.
+// If the command crashed with a panic, the output is parsed with ParseCrash
+// and the event is dispatched to the globally registered handlers and reporters.
+// The command path, process ID and exit code are recorded in the event metadata.
+//
+// The returned event is nil if the command did not crash.
+// The returned error is the one from cmd.Run, which carries the exit status.
+func Exec(cmd *exec.Cmd) (*PanicEvent, error) {
+	detector := &crashDetector{}
+	if cmd.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, detector)
+	} else {
+		cmd.Stderr = detector
+	}
+
+	err := cmd.Run()
+	detector.flush()
+	if err == nil || detector.crash.Len() == 0 {
+		return nil, err
+	}
+
+	event, parseErr := ParseCrash(&detector.crash)
+	if parseErr != nil {
+		return nil, err
+	}
+	event.Time = clockNow()
+	event.Metadata["command"] = cmd.Path
+	if state := cmd.ProcessState; state != nil {
+		event.Metadata["pid"] = strconv.Itoa(state.Pid())
+		event.Metadata["exit_code"] = strconv.Itoa(state.ExitCode())
+	}
+
+	event = dispatchRegistered(event)
+	return &event, err
+}
+
+// maxCrashOutput bounds the crash output kept by crashDetector.
+const maxCrashOutput = 4 << 20
+
+// crashDetector keeps the output from the last line which starts a Go panic.
+type crashDetector struct {
+	line  []byte
+	crash bytes.Buffer
+}
+
+func (d *crashDetector) Write(p []byte) (int, error) {
+	written := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			d.line = append(d.line, p[:min(len(p), maxCrashLine-len(d.line))]...)
+			break
+		}
+		d.line = append(d.line, p[:i+1]...)
+		d.scan()
+		p = p[i+1:]
+	}
+	return written, nil
+}
+
+// flush the last line without newline.
+func (d *crashDetector) flush() {
+	if len(d.line) > 0 {
+		d.scan()
+	}
+}
+
+func (d *crashDetector) scan() {
+	line := string(d.line)
+	d.line = d.line[:0]
+
+	if strings.HasPrefix(line, "panic: ") || strings.HasPrefix(line, "fatal error: ") {
+		d.crash.Reset()
+	} else if d.crash.Len() == 0 {
+		return
+	}
+	if d.crash.Len()+len(line) <= maxCrashOutput {
+		d.crash.WriteString(line)
+	}
+}
@@ -0,0 +1,60 @@
+package nice
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExecHelperProcess is run as the child process of TestExec.
+func TestExecHelperProcess(t *testing.T) {
+	switch os.Getenv("NICE_EXEC_HELPER") {
+	case "panic":
+		os.Stderr.WriteString("panic: logged but recovered\n")
+		panic("child crashed")
+	case "exit":
+		os.Exit(3)
+	}
+}
+
+func helperCommand(mode string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestExecHelperProcess$")
+	cmd.Env = append(os.Environ(), "NICE_EXEC_HELPER="+mode)
+	return cmd
+}
+
+func TestExec(t *testing.T) {
+	t.Run("crashed", func(t *testing.T) {
+		cleanRegistry(t)
+		var handled any
+		Register(Type[*CrashError](), func(artefact any) { handled = artefact })
+		var stderr bytes.Buffer
+		cmd := helperCommand("panic")
+		cmd.Stderr = &stderr
+
+		event, err := Exec(cmd)
+
+		var exitErr *exec.ExitError
+		assert.ErrorAs(t, err, &exitErr)
+		if assert.NotNil(t, event) {
+			assert.True(t, event.Handled)
+			assert.Equal(t, "child crashed", event.Message())
+			assert.Equal(t, "2", event.Metadata["exit_code"])
+			assert.NotEmpty(t, event.Stack)
+		}
+		assert.Equal(t, &CrashError{Message: "child crashed"}, handled)
+		assert.Contains(t, stderr.String(), "panic: child crashed", "Stderr is still written.")
+	})
+
+	t.Run("exited without crash", func(t *testing.T) {
+		cleanRegistry(t)
+
+		event, err := Exec(helperCommand("exit"))
+
+		assert.Error(t, err)
+		assert.Nil(t, event)
+	})
+}
@@ -0,0 +1,67 @@
+package nice
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Explanation is the trace of matching an artefact against targets, as returned by Explain.
+type Explanation struct {
+	// Type name of the artefact.
+	Type string
+	// Matched tells whether any target matches.
+	Matched bool
+	// Evaluations of the targets in order, up to the matched one.
+	Evaluations []Evaluation
+}
+
+// Evaluation of a single target.
+type Evaluation struct {
+	Target  string
+	Matched bool
+	Reason  string
+}
+
+// String renders the explanation one evaluation per line.
+func (e Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "artefact of type %s", e.Type)
+	if e.Matched {
+		b.WriteString(" matched\n")
+	} else {
+		b.WriteString(" did not match\n")
+	}
+	for _, evaluation := range e.Evaluations {
+		mark := "✗"
+		if evaluation.Matched {
+			mark = "✓"
+		}
+		fmt.Fprintf(&b, "  %s %s: %s\n", mark, evaluation.Target, evaluation.Reason)
+	}
+	return b.String()
+}
+
+// Explain returns how the artefact would be matched against the targets given to Tackle,
+// without panicking. A single Handler is explained as it is.
+// It lets users unit-test and debug their target setup.
+//
+//	explanation := nice.Explain(err, nice.Type[*os.PathError](), io.EOF)
+//	if !explanation.Matched {
+//		t.Error(explanation)
+//	}
+func Explain(artefact any, targets ...any) Explanation {
+	handler := Tackle(targets...)
+	if len(targets) == 1 {
+		handler = toHandler(targets[0])
+	}
+
+	explanation := Explanation{Type: PanicEvent{Artefact: artefact}.Type()}
+	explanation.Matched = handler.match(artefact, func(target string, matched bool, reason string) {
+		explanation.Evaluations = append(explanation.Evaluations, Evaluation{
+			Target:  target,
+			Matched: matched,
+			Reason:  reason,
+		})
+	})
+	return explanation
+}
@@ -0,0 +1,59 @@
+package nice_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain(t *testing.T) {
+	t.Run("matched", func(t *testing.T) {
+		pathErr := &os.PathError{Op: "open", Path: "/x", Err: os.ErrNotExist}
+
+		explanation := nice.Explain(pathErr, io.EOF, nice.Type[*os.PathError](), reflect.TypeFor[string]())
+
+		assert.Equal(t, nice.Explanation{
+			Type:    "*fs.PathError",
+			Matched: true,
+			Evaluations: []nice.Evaluation{
+				{Target: "type string", Matched: false, Reason: "artefact is of type *fs.PathError"},
+				{Target: `error "EOF" (*errors.errorString)`, Matched: false, Reason: "artefact is a different error"},
+				{Target: "type *fs.PathError", Matched: true, Reason: "matcher matched"},
+			},
+		}, explanation)
+	})
+
+	t.Run("not matched", func(t *testing.T) {
+		explanation := nice.Explain(7, reflect.TypeFor[error](), errors.New("mock error"))
+
+		assert.False(t, explanation.Matched)
+		assert.Equal(t, []nice.Evaluation{
+			{Target: "type error", Matched: false, Reason: "artefact is not an error"},
+			{Target: `error "mock error" (*errors.errorString)`, Matched: false, Reason: "artefact is not an error"},
+		}, explanation.Evaluations)
+		assert.Equal(t, "artefact of type int did not match\n"+
+			"  ✗ type error: artefact is not an error\n"+
+			"  ✗ error \"mock error\" (*errors.errorString): artefact is not an error\n",
+			explanation.String())
+		assert.Equal(t, []nice.Evaluation{
+			{Target: "type *fs.PathError", Matched: false, Reason: "errors are matched by value or as error"},
+		}, nice.Explain(&os.PathError{}, reflect.TypeFor[*os.PathError]()).Evaluations, "Errors match no type but the error type.")
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		explanation := nice.Explain("message", nice.Tackle(reflect.TypeFor[string]()))
+
+		assert.True(t, explanation.Matched)
+	})
+
+	t.Run("no target", func(t *testing.T) {
+		explanation := nice.Explain(errors.New("any error"))
+
+		assert.True(t, explanation.Matched, "Generic error is assumed as Tackle does.")
+	})
+}
@@ -0,0 +1,121 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+)
+
+// FileGuard tracks the files opened and locked in a protected block,
+// and closes them when the block ends, which also releases their advisory locks.
+// The zero value is ready to use.
+//
+//	var files nice.FileGuard
+//	defer files.Guard()
+//
+//	f, err := files.OpenFile(path, os.O_RDWR, 0)
+//	if err != nil {
+//		panic(err)
+//	}
+//	if err := files.Lock(f); err != nil {
+//		panic(err)
+//	}
+type FileGuard struct {
+	mu    sync.Mutex
+	files []*os.File
+}
+
+// Open opens the named file for reading, as os.Open, and tracks it.
+func (g *FileGuard) Open(name string) (*os.File, error) {
+	return g.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// OpenFile opens the named file, as os.OpenFile, and tracks it.
+func (g *FileGuard) OpenFile(name string, flag int, perm os.FileMode) (*os.File, error) {
+	f, err := os.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return g.Track(f), nil
+}
+
+// Track the file opened elsewhere.
+func (g *FileGuard) Track(f *os.File) *os.File {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.files = append(g.files, f)
+	return f
+}
+
+// Lock acquires an exclusive advisory lock (flock) on the file, blocking until available,
+// and tracks the file. The lock is released when the file is closed.
+// It returns errors.ErrUnsupported on platforms without flock.
+func (g *FileGuard) Lock(f *os.File) error {
+	if err := flock(f); err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, tracked := range g.files {
+		if tracked == f {
+			return nil
+		}
+	}
+	g.files = append(g.files, f)
+	return nil
+}
+
+// Close closes the tracked files in reverse order, releasing their locks,
+// and stops tracking them.
+func (g *FileGuard) Close() error {
+	g.mu.Lock()
+	files := g.files
+	g.files = nil
+	g.mu.Unlock()
+
+	var errs []error
+	for i := len(files) - 1; i >= 0; i-- {
+		if err := files[i].Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// paths of the tracked files.
+func (g *FileGuard) paths() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	paths := make([]string, len(g.files))
+	for i, f := range g.files {
+		paths[i] = f.Name()
+	}
+	return paths
+}
+
+// Guard closes the tracked files when the protected block ends.
+// On panic, the files are closed before the artefact is dispatched
+// to the globally registered handlers, as by nice.Guard,
+// with the file paths recorded as "files" in the event metadata,
+// separated by os.PathListSeparator.
+// It shall be deferred directly.
+func (g *FileGuard) Guard() {
+	if RecoveryDisabled {
+		_ = g.Close()
+		return
+	}
+	if artefact := recover(); artefact != nil {
+		paths := g.paths()
+		_ = g.Close()
+
+		event := newEvent(artefact)
+		if len(paths) > 0 {
+			event.Metadata = map[string]string{"files": strings.Join(paths, string(os.PathListSeparator))}
+		}
+		Fallthrough(recovered(context.Background(), event, "FileGuard"))
+		return
+	}
+	_ = g.Close()
+}
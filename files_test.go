@@ -0,0 +1,85 @@
+package nice
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileGuard(t *testing.T) {
+	t.Run("close before handling", func(t *testing.T) {
+		cleanRegistry(t)
+		dir := t.TempDir()
+		reporter := &mockReporter{}
+		AddReporter(reporter)
+		var tracked []*os.File
+		var closedWhileHandling bool
+		Register(reflect.TypeFor[string](), func(any) {
+			_, err := tracked[0].Stat()
+			closedWhileHandling = errors.Is(err, os.ErrClosed)
+		})
+
+		func() {
+			var files FileGuard
+			defer files.Guard()
+
+			f, err := files.OpenFile(filepath.Join(dir, "a.lock"), os.O_CREATE|os.O_RDWR, 0o600)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.NoError(t, files.Lock(f))
+			g, err := os.Create(filepath.Join(dir, "b.txt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tracked = []*os.File{f, files.Track(g)}
+			panic("write failed")
+		}()
+
+		assert.True(t, closedWhileHandling, "Files are closed before handling.")
+		if assert.Len(t, reporter.events, 1) {
+			assert.Equal(t,
+				filepath.Join(dir, "a.lock")+string(os.PathListSeparator)+filepath.Join(dir, "b.txt"),
+				reporter.events[0].Metadata["files"])
+		}
+		// The lock has been released.
+		f, err := os.OpenFile(filepath.Join(dir, "a.lock"), os.O_RDWR, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		var files FileGuard
+		assert.NoError(t, files.Lock(f))
+	})
+
+	t.Run("close on success", func(t *testing.T) {
+		var f *os.File
+		func() {
+			var files FileGuard
+			defer files.Guard()
+
+			var err error
+			f, err = files.OpenFile(filepath.Join(t.TempDir(), "c.txt"), os.O_CREATE|os.O_WRONLY, 0o600)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}()
+
+		_, err := f.Stat()
+		assert.ErrorIs(t, err, os.ErrClosed)
+	})
+
+	t.Run("fallthrough", func(t *testing.T) {
+		cleanRegistry(t)
+
+		assert.Panics(t, func() {
+			var files FileGuard
+			defer files.Guard()
+			panic(7)
+		})
+	})
+}
@@ -0,0 +1,51 @@
+package nice
+
+import (
+	"regexp"
+	"sync/atomic"
+)
+
+// FingerprintStrategy computes the fingerprint of an event, see SetFingerprint.
+type FingerprintStrategy func(event PanicEvent) string
+
+var fingerprintStrategy atomic.Pointer[FingerprintStrategy]
+
+// SetFingerprint replaces the strategy of Fingerprint, e.g. to group by a domain error code.
+// Passing nil restores DefaultFingerprint.
+func SetFingerprint(strategy FingerprintStrategy) {
+	if strategy == nil {
+		fingerprintStrategy.Store(nil)
+		return
+	}
+	fingerprintStrategy.Store(&strategy)
+}
+
+// Fingerprint tells the same panics apart from others, by the strategy set by SetFingerprint.
+// It is the grouping of SuppressStorms, Sample and nicehttp.DebugHandler,
+// for external reporters to group the events the same way.
+func Fingerprint(event PanicEvent) string {
+	if strategy := fingerprintStrategy.Load(); strategy != nil {
+		return (*strategy)(event)
+	}
+	return DefaultFingerprint(event)
+}
+
+var (
+	addressPattern = regexp.MustCompile(`\b0x[0-9a-fA-F]+`)
+	numberPattern  = regexp.MustCompile(`\b[0-9]+\b`)
+)
+
+// DefaultFingerprint is the type of the artefact, its message with addresses and numbers masked,
+// and the function of the top frame outside the standard library, if the event has a stack,
+// e.g. `runtime.boundsError: index out of range [N] with length N at main.parse`.
+func DefaultFingerprint(event PanicEvent) string {
+	message := numberPattern.ReplaceAllString(event.Message(), "N")
+	message = addressPattern.ReplaceAllString(message, "0x?")
+	fingerprint := event.Type() + ": " + message
+	for _, f := range event.Stack {
+		if !f.Standard() {
+			return fingerprint + " at " + f.Function
+		}
+	}
+	return fingerprint
+}
@@ -0,0 +1,49 @@
+package nice
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	event := PanicEvent{
+		Artefact: errors.New("index 12 out of range at 0xc000123abc"),
+		Stack: []Frame{
+			{Function: "runtime.goPanicIndex", File: "/go/src/runtime/panic.go", Line: 115},
+			{Function: "github.com/acme/app/parser.parse", File: "/src/parser.go", Line: 7},
+			{Function: "main.main", File: "/src/main.go", Line: 3},
+		},
+	}
+
+	assert.Equal(t, "*errors.errorString: index N out of range at 0x? at github.com/acme/app/parser.parse", Fingerprint(event))
+	other := event
+	other.Artefact = errors.New("index 3 out of range at 0xc000999000")
+	assert.Equal(t, Fingerprint(event), Fingerprint(other), "numbers and addresses are masked")
+	event.Stack = nil
+	assert.Equal(t, "*errors.errorString: index N out of range at 0x?", Fingerprint(event))
+
+	SetFingerprint(func(event PanicEvent) string { return "custom" })
+	t.Cleanup(func() { SetFingerprint(nil) })
+	assert.Equal(t, "custom", Fingerprint(event))
+	SetFingerprint(nil)
+	assert.Equal(t, DefaultFingerprint(event), Fingerprint(event))
+}
+
+func TestFingerprintStorm(t *testing.T) {
+	cleanRegistry(t)
+	SuppressStorms(1, time.Minute)
+	t.Cleanup(func() { SuppressStorms(0, 0) })
+	SetFingerprint(func(event PanicEvent) string { return "same" })
+	t.Cleanup(func() { SetFingerprint(nil) })
+	handled := 0
+	Register(reflect.TypeFor[string](), func(any) { handled++ })
+
+	guarded("one")
+	guarded("two")
+
+	assert.Equal(t, 1, handled, "the panics are the same by the strategy")
+}
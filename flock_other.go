@@ -0,0 +1,12 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package nice
+
+import (
+	"errors"
+	"os"
+)
+
+func flock(*os.File) error {
+	return errors.ErrUnsupported
+}
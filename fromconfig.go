@@ -0,0 +1,215 @@
+package nice
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of built-in handler declared in the document of FromConfig.
+const (
+	KindLog     = "log"
+	KindFile    = "file"
+	KindWebhook = "webhook"
+	KindMetrics = "metrics"
+)
+
+// Document declares the handlers registered by FromConfig.
+type Document struct {
+	Handlers []HandlerSpec `json:"handlers" yaml:"handlers"`
+}
+
+// HandlerSpec declares a built-in handler and its targets.
+type HandlerSpec struct {
+	// Name of the registration, by which it is configured with Reload.
+	Name string `json:"name" yaml:"name"`
+	// Kind of the built-in handler.
+	Kind string `json:"kind" yaml:"kind"`
+	// Targets handled. Generic error is handled if none is declared.
+	Targets []TargetSpec `json:"targets,omitempty" yaml:"targets,omitempty"`
+	// Path of the file written by the file handler.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// URL posted to by the webhook handler.
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// TargetSpec declares a target by type name and message pattern.
+// When both are declared, both shall match.
+type TargetSpec struct {
+	// Type name of the artefact, as matched by TypeName.
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Message is a regular expression, as matched by MessageMatches.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// ConfigOption provides FromConfig the dependencies of the built-in handlers.
+type ConfigOption func(*configDeps)
+
+type configDeps struct {
+	logger  *slog.Logger
+	metrics MetricsSink
+	client  *http.Client
+}
+
+// ConfigLogger sets the logger of the log handlers. Defaults to slog.Default().
+func ConfigLogger(logger *slog.Logger) ConfigOption {
+	return func(d *configDeps) { d.logger = logger }
+}
+
+// ConfigMetrics sets the sink of the metrics handlers, which are not available without.
+func ConfigMetrics(sink MetricsSink) ConfigOption {
+	return func(d *configDeps) { d.metrics = sink }
+}
+
+// ConfigHTTPClient sets the client of the webhook handlers.
+func ConfigHTTPClient(client *http.Client) ConfigOption {
+	return func(d *configDeps) { d.client = client }
+}
+
+// FromConfig registers the built-in handlers declared in the JSON or YAML document globally,
+// so the panic policy can live in deployment configuration.
+// A document starting with "{" is JSON, and any other is YAML.
+// Each handler is registered under its name, by which it is configured with Reload.
+// Nothing is registered if the document has any error.
+//
+//	{
+//		"handlers": [
+//			{"name": "audit", "kind": "file", "path": "/var/log/panics.jsonl"},
+//			{"name": "ops", "kind": "webhook", "url": "https://ops.example.com/hook",
+//			 "targets": [{"type": "*payments.DeclineError"}, {"message": "^timeout"}]}
+//		]
+//	}
+//
+// or
+//
+//	handlers:
+//	  - name: audit
+//	    kind: file
+//	    path: /var/log/panics.jsonl
+//	  - name: ops
+//	    kind: webhook
+//	    url: https://ops.example.com/hook
+//	    targets:
+//	      - type: "*payments.DeclineError"
+//	      - message: ^timeout
+func FromConfig(doc []byte, opts ...ConfigOption) error {
+	deps := configDeps{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(&deps)
+	}
+
+	var document Document
+	if trimmed := bytes.TrimSpace(doc); len(trimmed) == 0 || trimmed[0] != '{' {
+		decoder := yaml.NewDecoder(bytes.NewReader(doc))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&document); err != nil {
+			return fmt.Errorf("decode YAML document: %w", err)
+		}
+	} else {
+		decoder := json.NewDecoder(bytes.NewReader(doc))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&document); err != nil {
+			return fmt.Errorf("decode document: %w", err)
+		}
+	}
+
+	registrations := make([]registration, 0, len(document.Handlers))
+	var errs []error
+	for i, spec := range document.Handlers {
+		r, err := spec.registration(deps)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("handlers[%d] %q: %w", i, spec.Name, err))
+			continue
+		}
+		registrations = append(registrations, r)
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for _, r := range registrations {
+		register(r)
+	}
+	return nil
+}
+
+func (spec HandlerSpec) registration(deps configDeps) (registration, error) {
+	// Every built-in handler but metrics reports the stack.
+	r := registration{registerOptions: registerOptions{name: spec.Name, needsStack: spec.Kind != KindMetrics}}
+	if spec.Name == "" {
+		return r, errors.New("no name")
+	}
+
+	switch spec.Kind {
+	case KindLog:
+		r.handle = LogHandler(deps.logger)
+	case KindFile:
+		if spec.Path == "" {
+			return r, errors.New("no path for file handler")
+		}
+		r.handle = FileHandler(spec.Path)
+	case KindWebhook:
+		if spec.URL == "" {
+			return r, errors.New("no url for webhook handler")
+		}
+		r.handle = WebhookHandler(spec.URL, deps.client)
+	case KindMetrics:
+		if deps.metrics == nil {
+			return r, errors.New("no metrics sink provided by ConfigMetrics")
+		}
+		r.handle = MetricsHandler(deps.metrics)
+	default:
+		return r, fmt.Errorf("unknown kind %q", spec.Kind)
+	}
+
+	targets := make([]any, 0, len(spec.Targets))
+	for _, t := range spec.Targets {
+		matcher, err := t.matcher()
+		if err != nil {
+			return r, err
+		}
+		targets = append(targets, matcher)
+	}
+	r.handler = Tackle(targets...)
+	return r, nil
+}
+
+func (spec TargetSpec) matcher() (Matcher, error) {
+	var matchers []Matcher
+	if spec.Type != "" {
+		matchers = append(matchers, TypeName(spec.Type))
+	}
+	if spec.Message != "" {
+		pattern, err := regexp.Compile(spec.Message)
+		if err != nil {
+			return nil, fmt.Errorf("target message: %w", err)
+		}
+		matchers = append(matchers, MessageMatches(pattern))
+	}
+	if len(matchers) == 0 {
+		return nil, errors.New("target without type or message")
+	}
+
+	descriptions := make([]string, len(matchers))
+	for i, m := range matchers {
+		descriptions[i] = describeTarget(m)
+	}
+	return describedMatcher{
+		match: func(artefact any) bool {
+			for _, m := range matchers {
+				if !m.Match(artefact) {
+					return false
+				}
+			}
+			return true
+		},
+		description: strings.Join(descriptions, " and "),
+	}, nil
+}
@@ -0,0 +1,98 @@
+package nice
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromConfig(t *testing.T) {
+	t.Run("register declared handlers", func(t *testing.T) {
+		cleanRegistry(t)
+		path := filepath.Join(t.TempDir(), "panics.jsonl")
+		sink := &mockSink{}
+		doc := `{
+			"handlers": [
+				{"name": "timeouts", "kind": "metrics", "targets": [{"message": "^timeout"}]},
+				{"name": "audit", "kind": "file", "path": "` + path + `", "targets": [{"type": "string"}]}
+			]
+		}`
+
+		assert.NoError(t, FromConfig([]byte(doc), ConfigMetrics(sink)))
+		guarded(errors.New("timeout after 5s"))
+		guarded("audited")
+
+		assert.Equal(t, []string{MetricPanics}, sink.counts)
+		written, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Contains(t, string(written), `"message":"audited"`)
+	})
+
+	t.Run("YAML document", func(t *testing.T) {
+		cleanRegistry(t)
+		sink := &mockSink{}
+		doc := `# panic policy
+handlers:
+- name: timeouts
+  kind: metrics
+  targets:
+    - message: '^timeout' # of the client
+    - type: "*errors.errorString"
+      message: "deadline: exceeded"
+`
+
+		assert.NoError(t, FromConfig([]byte(doc), ConfigMetrics(sink)))
+		guarded(errors.New("timeout after 5s"))
+		guarded(errors.New("deadline: exceeded"))
+
+		assert.Equal(t, []string{MetricPanics, MetricPanics}, sink.counts)
+		assert.Equal(t, "timeouts", loadRegistry().registrations[0].name)
+	})
+
+	t.Run("invalid YAML document", func(t *testing.T) {
+		cleanRegistry(t)
+
+		assert.ErrorContains(t, FromConfig([]byte("handlers: [{name: log")), "decode YAML document: yaml: line 1")
+		assert.ErrorContains(t, FromConfig([]byte("handlers:\n  - name: log\n   kind: log")), "decode YAML document")
+		assert.ErrorContains(t, FromConfig([]byte("handlers:\n  - name: log\n    color: red")), "line 3: field color not found")
+		assert.ErrorContains(t, FromConfig([]byte("handlers: [{name: log}]")), `handlers[0] "log": unknown kind ""`, "Flow collections are decoded.")
+		assert.Empty(t, loadRegistry().registrations)
+	})
+
+	t.Run("configurable by name", func(t *testing.T) {
+		cleanRegistry(t)
+		sink := &mockSink{}
+		doc := `{"handlers": [{"name": "metrics", "kind": "metrics"}]}`
+
+		assert.NoError(t, FromConfig([]byte(doc), ConfigMetrics(sink)))
+		Reload(Config{Handlers: map[string]HandlerConfig{"metrics": {Disabled: true}}})
+		func() {
+			defer func() { _ = recover() }()
+			guarded(errors.New("disabled"))
+		}()
+
+		assert.Empty(t, sink.counts)
+	})
+
+	t.Run("invalid document registers nothing", func(t *testing.T) {
+		cleanRegistry(t)
+		doc := `{
+			"handlers": [
+				{"name": "ok", "kind": "log"},
+				{"name": "metrics", "kind": "metrics"},
+				{"name": "pattern", "kind": "log", "targets": [{"message": "("}]},
+				{"name": "unknown", "kind": "sentry"}
+			]
+		}`
+
+		err := FromConfig([]byte(doc))
+
+		assert.ErrorContains(t, err, `handlers[1] "metrics"`)
+		assert.ErrorContains(t, err, `handlers[2] "pattern"`)
+		assert.ErrorContains(t, err, `handlers[3] "unknown"`)
+		assert.Empty(t, loadRegistry().registrations)
+	})
+}
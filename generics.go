@@ -0,0 +1,50 @@
+package nice
+
+import "reflect"
+
+// The generic targets are the v2 surface of the targets, keeping reflect out of the calling code:
+// Type and OnType give the targets of types, error values and Matchers are given as they are,
+// and As and RegisterType take the handle funcs of typed artefacts.
+// They are accepted by the v1 API as any target, which keeps accepting reflect.Type targets too.
+
+// Type matches artefacts of type T, or implementing T if T is an interface.
+// It is the generic counterpart of a reflect.Type target,
+// and is accepted by Tackle, Route, Register and the Builder as any Matcher.
+//
+//	defer nice.Tackle(nice.Type[*os.PathError]()).With(handle)
+func Type[T any]() Matcher {
+	return describedMatcher{
+		match: func(artefact any) bool {
+			_, matched := artefact.(T)
+			return matched
+		},
+		description: "type " + reflect.TypeFor[T]().String(),
+	}
+}
+
+// As adapts a handle func of T to the handle func of artefacts.
+// Artefacts not of type T are ignored, so it is meant to be paired with Type[T].
+func As[T any](handle func(artefact T)) func(artefact any) {
+	return func(artefact any) {
+		if t, matched := artefact.(T); matched {
+			handle(t)
+		}
+	}
+}
+
+// OnType pairs Type[T] with the typed handle func.
+//
+//	defer nice.Route(
+//		nice.OnType(func(err *TimeoutError) { retry(err) }),
+//		nice.OnType(func(err error) { log.Print(err) }),
+//	).Guard()
+func OnType[T any](handle func(artefact T)) Pair {
+	return On(Type[T](), As(handle))
+}
+
+// RegisterType registers the typed handle func for the artefacts of type T globally, as Register with Type[T].
+//
+//	nice.RegisterType(func(err *os.PathError) { log.Print(err.Path) })
+func RegisterType[T any](handle func(artefact T), opts ...RegisterOption) {
+	Register(Type[T](), As(handle), opts...)
+}
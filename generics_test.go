@@ -0,0 +1,39 @@
+package nice_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestType(t *testing.T) {
+	assert.True(t, nice.Type[string]().Match("message"))
+	assert.False(t, nice.Type[string]().Match(7))
+	assert.True(t, nice.Type[error]().Match(errors.New("any")), "Interface matches its implementations.")
+	assert.True(t, nice.Type[*fs.PathError]().Match(&fs.PathError{}))
+	assert.False(t, nice.Type[*fs.PathError]().Match(errors.New("other")))
+}
+
+func TestTypeTackled(t *testing.T) {
+	handler := &mockHandler{}
+	assert.NotPanics(t, func() {
+		defer nice.Tackle(nice.Type[*fs.PathError]()).With(handler.Handle)
+		panic(&fs.PathError{Op: "open"})
+	})
+	assertExecuted(t, handler)
+}
+
+func TestOnType(t *testing.T) {
+	var op string
+	assert.NotPanics(t, func() {
+		defer nice.Route(
+			nice.OnType(func(s string) { op = "string " + s }),
+			nice.OnType(func(err *fs.PathError) { op = err.Op }),
+		).Guard()
+		panic(&fs.PathError{Op: "open"})
+	})
+	assert.Equal(t, "open", op)
+}
@@ -0,0 +1,45 @@
+package nice
+
+import (
+	"errors"
+	"sync"
+)
+
+// Group runs goroutines protected as by Protect, for batch fan-outs.
+// A panic of a goroutine does not crash the process, nor stop the other goroutines.
+// The zero Group is ready to use.
+//
+//	var g nice.Group
+//	for _, item := range batch {
+//		g.Go(func() { process(item) })
+//	}
+//	if err := g.Wait(); err != nil {
+//		log.Print(err) // every failure, each with its own stack
+//	}
+type Group struct {
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+// Go calls fn in a new goroutine of the group.
+func (g *Group) Go(fn func()) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := Protect(fn); err != nil {
+			g.mu.Lock()
+			g.errs = append(g.errs, err)
+			g.mu.Unlock()
+		}
+	}()
+}
+
+// Wait for the goroutines of the group, returning the *PanicError of every goroutine which panicked,
+// joined as by errors.Join, in the order they panicked. It returns nil if none panicked.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.errs...)
+}
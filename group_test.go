@@ -0,0 +1,39 @@
+package nice
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup(t *testing.T) {
+	var g Group
+	for i := range 5 {
+		g.Go(func() {
+			if i%2 == 1 {
+				panic(fmt.Errorf("item %d", i))
+			}
+		})
+	}
+
+	err := g.Wait()
+
+	assert.Error(t, err)
+	joined, isJoin := err.(interface{ Unwrap() []error })
+	assert.True(t, isJoin)
+	members := joined.Unwrap()
+	assert.Len(t, members, 2, "every failure is reported, not just the first")
+	for _, member := range members {
+		var panicErr *PanicError
+		assert.True(t, errors.As(member, &panicErr))
+		assert.NotEmpty(t, panicErr.Stack, "each failure retains its own stack")
+		assert.Contains(t, panicErr.Stack[0].Function, "TestGroup")
+	}
+	assert.ElementsMatch(t, []string{"panic: item 1", "panic: item 3"}, []string{members[0].Error(), members[1].Error()})
+
+	var empty Group
+	empty.Go(func() {})
+	assert.NoError(t, empty.Wait())
+}
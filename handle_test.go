@@ -17,7 +17,7 @@ type mockHandler struct {
 
 func (m *mockHandler) Handle(artefact any) {
 	m.Executed = true
-	log.Printf("It panicked. Error: %+v", artefact)
+	log.Printf("It panicked. Error: %s", nice.Stringify(artefact))
 }
 
 func assertExecuted(t *testing.T, h *mockHandler) {
@@ -156,6 +156,84 @@ func TestHandler(t *testing.T) {
 	})
 }
 
+func TestHandlerWithFlag(t *testing.T) {
+	t.Run("handled", func(t *testing.T) {
+		var handled bool
+		func() {
+			defer nice.Tackle().WithFlag(&handled, func(any) {})
+			panic(errors.New("mock error"))
+		}()
+
+		assert.True(t, handled)
+	})
+
+	t.Run("not panicked", func(t *testing.T) {
+		var handled bool
+		func() {
+			defer nice.Tackle().WithFlag(&handled, func(any) {})
+		}()
+
+		assert.False(t, handled)
+	})
+
+	t.Run("no matched artefact type", func(t *testing.T) {
+		var handled bool
+		func() {
+			defer func() {
+				if artefact := recover(); artefact == nil {
+					t.Error("Unhandled panic did not fallthrough.")
+				}
+			}()
+			defer nice.Tackle().WithFlag(&handled, func(any) {})
+			panic(7)
+		}()
+
+		assert.False(t, handled)
+	})
+}
+
+func TestHandlerFinally(t *testing.T) {
+	t.Run("after handling", func(t *testing.T) {
+		var executed []string
+		func() {
+			defer nice.Tackle().
+				Finally(func() { executed = append(executed, "1st finally") }, func() { executed = append(executed, "2nd finally") }).
+				With(func(any) { executed = append(executed, "handle") })
+			panic(errors.New("mock error"))
+		}()
+
+		assert.Equal(t, []string{"handle", "1st finally", "2nd finally"}, executed)
+	})
+
+	t.Run("not panicked", func(t *testing.T) {
+		var executed []string
+		func() {
+			defer nice.Tackle().
+				Finally(func() { executed = append(executed, "finally") }).
+				With(func(any) { executed = append(executed, "handle") })
+		}()
+
+		assert.Equal(t, []string{"finally"}, executed)
+	})
+
+	t.Run("no matched artefact type", func(t *testing.T) {
+		var executed []string
+		func() {
+			defer func() {
+				if artefact := recover(); artefact == nil {
+					t.Error("Unhandled panic did not fallthrough.")
+				}
+			}()
+			defer nice.Tackle().
+				Finally(func() { executed = append(executed, "finally") }).
+				With(func(any) { executed = append(executed, "handle") })
+			panic(7)
+		}()
+
+		assert.Equal(t, []string{"finally"}, executed)
+	})
+}
+
 func ExampleTackle() {
 
 	var customError = &struct {
@@ -186,3 +264,28 @@ func ExampleTackle() {
 	panicFunc()
 	// Output: It panicked. Error: error: custom string
 }
+
+func TestWithHandles(t *testing.T) {
+	errSync := errors.New("sync failed")
+	var calls []string
+	record := func(name string) func(any) {
+		return func(artefact any) {
+			calls = append(calls, name+": "+artefact.(error).Error())
+		}
+	}
+
+	func() {
+		defer nice.Tackle(errSync).With(record("log"), record("report"), record("cleanup"))
+		panic(errSync)
+	}()
+	assert.Equal(t, []string{"log: sync failed", "report: sync failed", "cleanup: sync failed"}, calls)
+
+	calls = nil
+	var handled bool
+	func() {
+		defer nice.Tackle(errSync).WithFlag(&handled, record("log"), record("retry"))
+		panic(errSync)
+	}()
+	assert.True(t, handled)
+	assert.Equal(t, []string{"log: sync failed", "retry: sync failed"}, calls)
+}
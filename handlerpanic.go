@@ -0,0 +1,73 @@
+package nice
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// HandlerPanicPolicy decides what happens when a handle func or a reporter panics while handling an artefact.
+type HandlerPanicPolicy int
+
+const (
+	// PropagateOriginal panics again with the original artefact, which the handler's panic would otherwise have replaced.
+	// It is the default.
+	PropagateOriginal HandlerPanicPolicy = iota
+	// Suppress logs the handler's panic and continues, as if the artefact had been handled.
+	// A buggy reporter never crashes the process it is supposed to protect.
+	Suppress
+	// PropagateBoth panics with the original artefact and the handler's panic joined, as by errors.Join.
+	// Artefacts which are not errors are joined as PanicValue.
+	PropagateBoth
+)
+
+var handlerPanicPolicy atomic.Int32
+
+// OnHandlerPanic sets the policy for the handle funcs and the reporters which panic,
+// so operators decide whether a buggy one may ever crash the process.
+// They run under their own recover, so the original artefact is never lost to the handler's panic,
+// which is logged and attached to the event as HandlerPanic.
+// The policy applies once all reporters ran; summaries of SuppressStorms only log.
+//
+//	nice.OnHandlerPanic(nice.Suppress)
+func OnHandlerPanic(policy HandlerPanicPolicy) {
+	handlerPanicPolicy.Store(int32(policy))
+}
+
+// runHandle runs the handle func, returning its panic if any.
+func runHandle[T any](handle func(T), value T) (handlerPanic any) {
+	defer func() {
+		handlerPanic = recover()
+	}()
+	handle(value)
+	return nil
+}
+
+// handlerPanicked applies the policy to the panic of the handler of the artefact.
+func handlerPanicked(artefact, handlerPanic any) {
+	logError(fmt.Errorf("handler panicked while handling %s: %s",
+		Stringify(artefact, MaxBytes(256)), Stringify(handlerPanic, MaxBytes(256))))
+	switch HandlerPanicPolicy(handlerPanicPolicy.Load()) {
+	case PropagateOriginal:
+		panic(artefact)
+	case PropagateBoth:
+		panic(errors.Join(asError(artefact), asError(handlerPanic)))
+	}
+}
+
+// PanicValue is a panic value which is not an error, as joined by PropagateBoth.
+type PanicValue struct {
+	Value any
+}
+
+// Error formats the value by Stringify.
+func (v PanicValue) Error() string {
+	return Stringify(v.Value)
+}
+
+func asError(value any) error {
+	if err, isError := value.(error); isError {
+		return err
+	}
+	return PanicValue{Value: value}
+}
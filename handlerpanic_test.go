@@ -0,0 +1,116 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerPanic(t *testing.T) {
+	errOriginal := errors.New("original")
+	buggy := func(any) { panic("buggy handler") }
+
+	t.Run("Propagate original", func(t *testing.T) {
+		assert.PanicsWithValue(t, errOriginal, func() {
+			defer Tackle(errOriginal).With(buggy)
+			panic(errOriginal)
+		})
+		assert.PanicsWithValue(t, errOriginal, func() {
+			defer Route(On(errOriginal, buggy)).Guard()
+			panic(errOriginal)
+		})
+		assert.PanicsWithValue(t, errOriginal, func() {
+			defer Route().Default(buggy).Guard()
+			panic(errOriginal)
+		}, "The default handle func runs under its own recover.")
+		assert.PanicsWithValue(t, errOriginal, func() {
+			defer New().Default(buggy).Guard()
+			panic(errOriginal)
+		})
+	})
+
+	t.Run("Suppress", func(t *testing.T) {
+		OnHandlerPanic(Suppress)
+		t.Cleanup(func() { OnHandlerPanic(PropagateOriginal) })
+
+		assert.NotPanics(t, func() {
+			defer Tackle(errOriginal).With(buggy)
+			panic(errOriginal)
+		})
+		assert.NotPanics(t, func() {
+			defer Route(On(errOriginal, buggy)).Guard()
+			panic(errOriginal)
+		})
+		assert.NotPanics(t, func() {
+			defer Route().Default(buggy).Guard()
+			panic(errOriginal)
+		})
+		assert.NotPanics(t, func() {
+			defer New().Default(buggy).Guard()
+			panic(errOriginal)
+		})
+	})
+
+	t.Run("Registered", func(t *testing.T) {
+		cleanRegistry(t)
+		reporter := &mockReporter{}
+		AddReporter(reporter)
+		Register(errOriginal, buggy)
+
+		assert.PanicsWithValue(t, errOriginal, func() {
+			Dispatch(context.Background(), errOriginal)
+		})
+		assert.Len(t, reporter.events, 1)
+		assert.Equal(t, "buggy handler", reporter.events[0].HandlerPanic, "the reporters receive the handler's panic")
+
+		OnHandlerPanic(Suppress)
+		t.Cleanup(func() { OnHandlerPanic(PropagateOriginal) })
+		event := Dispatch(context.Background(), errOriginal)
+		assert.True(t, event.Handled)
+		assert.Equal(t, "buggy handler", event.HandlerPanic)
+		assert.Contains(t, event.String(), "\thandler panicked: buggy handler\n")
+	})
+}
+
+func TestHandlerPanicPolicies(t *testing.T) {
+	errOriginal := errors.New("original")
+	t.Cleanup(func() { OnHandlerPanic(PropagateOriginal) })
+
+	t.Run("Propagate both", func(t *testing.T) {
+		OnHandlerPanic(PropagateBoth)
+		var propagated any
+		func() {
+			defer func() { propagated = recover() }()
+			defer Tackle(errOriginal).With(func(any) { panic("buggy handler") })
+			panic(errOriginal)
+		}()
+
+		err, isError := propagated.(error)
+		assert.True(t, isError)
+		assert.ErrorIs(t, err, errOriginal)
+		assert.ErrorIs(t, err, PanicValue{Value: "buggy handler"})
+		assert.Equal(t, "original\nbuggy handler", err.Error())
+	})
+
+	t.Run("Buggy reporter", func(t *testing.T) {
+		cleanRegistry(t)
+		OnHandlerPanic(Suppress)
+		next := &mockReporter{}
+		AddReporter(ReporterFunc(func(PanicEvent) { panic("buggy reporter") }))
+		AddReporter(next)
+		Register(errOriginal, func(any) {})
+
+		event := Dispatch(context.Background(), errOriginal)
+
+		assert.True(t, event.Handled)
+		assert.Equal(t, "buggy reporter", event.HandlerPanic)
+		assert.Len(t, next.events, 1, "the next reporters still receive the event")
+
+		OnHandlerPanic(PropagateOriginal)
+		assert.PanicsWithValue(t, errOriginal, func() {
+			Dispatch(context.Background(), errOriginal)
+		})
+	})
+}
@@ -0,0 +1,170 @@
+package nice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultWebhookTimeout bounds the request of WebhookHandler with the default client.
+const DefaultWebhookTimeout = 5 * time.Second
+
+// LogHandler returns an event handle func logging the event to the logger.
+// The log level follows the severity of the event.
+func LogHandler(logger *slog.Logger) func(event PanicEvent) {
+	return func(event PanicEvent) {
+		if event.SampledOut() {
+			return
+		}
+		attrs := []slog.Attr{
+			slog.String("type", event.TypeName()),
+			slog.String("message", event.Message()),
+			slog.Bool("handled", event.Handled),
+		}
+		if len(event.Stack) > 0 {
+			attrs = append(attrs, slog.String("frame", event.Stack[0].Function+" "+
+				event.Stack[0].File+":"+strconv.Itoa(event.Stack[0].Line)))
+		}
+		for _, k := range sortedKeys(event.Metadata) {
+			attrs = append(attrs, slog.String(k, event.Metadata[k]))
+		}
+		if len(event.Tags) > 0 {
+			tags := make([]any, 0, len(event.Tags))
+			for _, k := range sortedKeys(event.Tags) {
+				tags = append(tags, slog.String(k, event.Tags[k]))
+			}
+			attrs = append(attrs, slog.Group("tags", tags...))
+		}
+		logger.LogAttrs(event.Context(), severityLevel(event.Severity), "panic tackled", attrs...)
+	}
+}
+
+func severityLevel(s Severity) slog.Level {
+	switch s {
+	case SeverityDebug:
+		return slog.LevelDebug
+	case SeverityInfo:
+		return slog.LevelInfo
+	case SeverityWarning:
+		return slog.LevelWarn
+	case SeverityCritical:
+		return slog.LevelError + 4
+	default:
+		return slog.LevelError
+	}
+}
+
+// FileHandler returns an event handle func appending the event as a JSON line
+// to the file at path, which is created if it does not exist.
+func FileHandler(path string) func(event PanicEvent) {
+	var mu sync.Mutex
+	return func(event PanicEvent) {
+		if event.SampledOut() {
+			return
+		}
+		line, err := json.Marshal(event)
+		if err != nil {
+			logError(fmt.Errorf("encode event: %w", err))
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			logError(err)
+			return
+		}
+		defer f.Close()
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			logError(err)
+		}
+	}
+}
+
+// WebhookHandler returns an event handle func posting the event as JSON to the url.
+// A nil client defaults to one with DefaultWebhookTimeout.
+// With RetryAttempts, a failed delivery, by a transport error or a 5xx status, is retried
+// after the backoff set by WithBackoff, or DefaultBackoff, within the context of the event.
+//
+//	nice.RegisterEvent(reflect.TypeFor[error](), nice.WebhookHandler(url, nil, nice.RetryAttempts(3)))
+func WebhookHandler(url string, client *http.Client, opts ...RetryOption) func(event PanicEvent) {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultWebhookTimeout}
+	}
+	c := newRetryConfig(DefaultBackoff, opts)
+	return func(event PanicEvent) {
+		if event.SampledOut() {
+			return
+		}
+		body, err := json.Marshal(event)
+		if err != nil {
+			logError(fmt.Errorf("encode event: %w", err))
+			return
+		}
+		for attempt := range max(c.attempts, 1) {
+			if attempt > 0 {
+				if err := Sleep(event.Context(), c.backoff, attempt); err != nil {
+					logError(fmt.Errorf("post event: give up retrying: %w", err))
+					return
+				}
+			}
+			retriable, err := postEvent(event.Context(), client, url, body)
+			if err == nil {
+				return
+			}
+			logError(err)
+			if !retriable {
+				return
+			}
+		}
+	}
+}
+
+// postEvent posts the encoded event, telling whether a failure is worth retrying.
+func postEvent(ctx context.Context, client *http.Client, url string, body []byte) (retriable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("post event: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("post event: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode >= 500, fmt.Errorf("post event: %s", resp.Status)
+	}
+	return false, nil
+}
+
+// MetricsHandler returns an event handle func counting the events
+// as MetricPanics, labelled with the artefact type, the severity and the tags.
+func MetricsHandler(sink MetricsSink) func(event PanicEvent) {
+	return func(event PanicEvent) {
+		labels := map[string]string{
+			"type":     event.TypeName(),
+			"severity": event.Severity.String(),
+		}
+		for k, v := range event.Tags {
+			if _, reserved := labels[k]; !reserved {
+				labels[k] = v
+			}
+		}
+		sink.Count(MetricPanics, labels)
+	}
+}
+
+// logError reports errors of the built-in handlers, which have no caller to return to.
+func logError(err error) {
+	log.Printf("nice: %v", err)
+}
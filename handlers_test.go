@@ -0,0 +1,120 @@
+package nice
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mockEvent() PanicEvent {
+	return PanicEvent{
+		Artefact: errors.New("mock error"),
+		Handled:  true,
+		Severity: SeverityWarning,
+		Stack:    []Frame{{Function: "main.run", File: "/src/main.go", Line: 9}},
+		Metadata: map[string]string{"request": "42"},
+	}
+}
+
+type mockSink struct {
+	counts []string
+	labels []map[string]string
+}
+
+func (s *mockSink) Count(name string, labels map[string]string) {
+	s.counts = append(s.counts, name)
+	s.labels = append(s.labels, labels)
+}
+
+func TestLogHandler(t *testing.T) {
+	var output bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&output, nil))
+
+	LogHandler(logger)(mockEvent())
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "panic tackled", record["msg"])
+	assert.Equal(t, "*errors.errorString", record["type"])
+	assert.Equal(t, "mock error", record["message"])
+	assert.Equal(t, "main.run /src/main.go:9", record["frame"])
+	assert.Equal(t, "42", record["request"])
+}
+
+func TestFileHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "panics.jsonl")
+	handle := FileHandler(path)
+
+	handle(mockEvent())
+	handle(mockEvent())
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	lines := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record map[string]any
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		assert.Equal(t, "mock error", record["message"])
+		lines++
+	}
+	assert.Equal(t, 2, lines)
+}
+
+func TestWebhookHandler(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		received, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	WebhookHandler(server.URL, nil)(mockEvent())
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal(received, &record))
+	assert.Equal(t, "mock error", record["message"])
+	assert.Equal(t, "warning", record["severity"])
+}
+
+func TestWebhookHandlerRetry(t *testing.T) {
+	var statuses []int
+	respond := []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := respond[len(statuses)]
+		statuses = append(statuses, status)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	WebhookHandler(server.URL, nil, RetryAttempts(5), WithBackoff(ConstantBackoff(time.Millisecond)))(mockEvent())
+	assert.Equal(t, respond, statuses, "5xx is retried until delivered")
+
+	statuses, respond = nil, []int{http.StatusBadRequest}
+	WebhookHandler(server.URL, nil, RetryAttempts(5), WithBackoff(ConstantBackoff(time.Millisecond)))(mockEvent())
+	assert.Equal(t, respond, statuses, "4xx is not retried")
+}
+
+func TestMetricsHandler(t *testing.T) {
+	sink := &mockSink{}
+
+	MetricsHandler(sink)(mockEvent())
+
+	assert.Equal(t, []string{MetricPanics}, sink.counts)
+	assert.Equal(t, map[string]string{"type": "*errors.errorString", "severity": "warning"}, sink.labels[0])
+}
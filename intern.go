@@ -0,0 +1,72 @@
+package nice
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/antonyho/nice/dispatch"
+)
+
+// maxInternedTargets is the most targets of a Handler to be interned.
+const maxInternedTargets = 4
+
+// maxInterned bounds the interned Handlers, against targets built dynamically.
+const maxInterned = 1024
+
+type internKey [maxInternedTargets + 1]any
+
+// interned caches the Handlers of TackleCached by their targets.
+var interned struct {
+	handlers sync.Map
+	size     atomic.Int64
+}
+
+// TackleCached returns the Handler of Tackle for the targets,
+// reusing the one previously made for the same targets,
+// so Handlers constructed inside hot loops do not allocate in every iteration.
+//
+//	for _, job := range jobs {
+//		func() {
+//			defer nice.TackleCached(ErrSkipped).With(logSkipped)
+//			job.Run()
+//		}()
+//	}
+//
+// Targets are the same if they are equal by ==, in the same order.
+// Handlers of more than 4 targets, or of targets not hashable, such as most Matchers
+// and errors holding a slice in an interface field,
+// are not cached and are made by Tackle every time.
+func TackleCached(targets ...any) Handler {
+	key, cacheable := newInternKey(targets)
+	if !cacheable {
+		return Tackle(targets...)
+	}
+	if h, cached := interned.handlers.Load(key); cached {
+		return h.(Handler)
+	}
+
+	h := Tackle(targets...)
+	if interned.size.Load() < maxInterned {
+		if _, loaded := interned.handlers.LoadOrStore(key, h); !loaded {
+			interned.size.Add(1)
+		}
+	}
+	return h
+}
+
+// newInternKey keys the targets, with the number of them
+// so that a trailing nil target is told apart.
+func newInternKey(targets []any) (internKey, bool) {
+	var key internKey
+	if len(targets) > maxInternedTargets {
+		return key, false
+	}
+	key[0] = len(targets)
+	for i, t := range targets {
+		if !dispatch.Hashable(t) {
+			return key, false
+		}
+		key[i+1] = t
+	}
+	return key, true
+}
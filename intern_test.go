@@ -0,0 +1,54 @@
+package nice
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTackleCached(t *testing.T) {
+	errCached := errors.New("cached")
+	h := TackleCached(errCached, reflect.TypeFor[string]())
+
+	assert.Equal(t, Tackle(errCached, reflect.TypeFor[string]()), h)
+	assert.True(t, h.matches(errCached))
+	assert.True(t, h.matches("message"))
+
+	again := TackleCached(errCached, reflect.TypeFor[string]())
+	assert.Same(t, &h.errorTypes[0], &again.errorTypes[0], "The Handler is reused.")
+	other := TackleCached(reflect.TypeFor[string](), errCached)
+	assert.NotSame(t, &h.errorTypes[0], &other.errorTypes[0], "Targets in other order are another Handler.")
+}
+
+func TestTackleCachedUncacheable(t *testing.T) {
+	matcher := TypeName("string")
+	h := TackleCached(matcher)
+	assert.True(t, h.matches("message"), "Uncomparable targets are tackled without cache.")
+
+	many := []any{reflect.TypeFor[int](), reflect.TypeFor[int8](), reflect.TypeFor[int16](), reflect.TypeFor[int32](), reflect.TypeFor[int64]()}
+	_, cacheable := newInternKey(many)
+	assert.False(t, cacheable)
+	assert.True(t, TackleCached(many...).matches(int64(1)))
+
+	errUnhashable := unhashableError{V: []int{1}}
+	assert.NotPanics(t, func() { h = TackleCached(errUnhashable) }, "Targets not hashable are tackled without cache.")
+	_, cacheable = newInternKey([]any{errUnhashable})
+	assert.False(t, cacheable)
+	assert.False(t, h.matches(unhashableError{V: 1}))
+}
+
+// unhashableError is of a comparable type, but not hashable while holding a slice.
+type unhashableError struct{ V any }
+
+func (unhashableError) Error() string { return "unhashable" }
+
+func TestTackleCachedAllocation(t *testing.T) {
+	errCached := errors.New("cached")
+	TackleCached(errCached)
+	allocs := testing.AllocsPerRun(100, func() {
+		defer TackleCached(errCached).With(func(any) {})
+	})
+	assert.Zero(t, allocs)
+}
@@ -0,0 +1,21 @@
+//go:build cgo
+
+// Package cgocallback calls Go through a C callback, to test GuardCallback across the cgo boundary.
+package cgocallback
+
+/*
+int goCallback(int n);
+*/
+import "C"
+
+import "github.com/antonyho/nice"
+
+// callback is called by goCallback, as registered by Invoke.
+var callback func(n int) int
+
+//export goCallback
+func goCallback(n C.int) C.int {
+	return nice.GuardCallback("goCallback", C.int(-1), func() C.int {
+		return C.int(callback(int(n)))
+	})
+}
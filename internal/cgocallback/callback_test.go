@@ -0,0 +1,24 @@
+//go:build cgo
+
+package cgocallback
+
+import (
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuardCallback(t *testing.T) {
+	nice.KeepRecent(1)
+	t.Cleanup(func() { nice.KeepRecent(0) })
+
+	assert.Equal(t, 4, Invoke(2, func(n int) int { return n * 2 }))
+
+	result := Invoke(0, func(n int) int { return 1 / n })
+	assert.Equal(t, -1, result, "C gets the error code rather than crashing")
+	if recent := nice.Recent(1); assert.Len(t, recent, 1) {
+		assert.Equal(t, "goCallback", recent[0].Metadata["cgo_callback"])
+		assert.Equal(t, "runtime error: integer divide by zero", recent[0].Message())
+	}
+}
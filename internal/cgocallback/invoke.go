@@ -0,0 +1,19 @@
+//go:build cgo
+
+package cgocallback
+
+/*
+int goCallback(int n);
+
+static int invoke(int n) {
+	return goCallback(n);
+}
+*/
+import "C"
+
+// Invoke has C call fn with n back, through goCallback, returning the result to Go.
+// It is not safe for concurrent use.
+func Invoke(n int, fn func(n int) int) int {
+	callback = fn
+	return int(C.invoke(C.int(n)))
+}
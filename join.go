@@ -0,0 +1,82 @@
+package nice
+
+import (
+	"sync/atomic"
+
+	"github.com/antonyho/nice/dispatch"
+)
+
+// Member of a joined error artefact, as by errors.Join, is passed to the handle func
+// when the member matched a target but the joined error did not.
+//
+//	defer nice.Tackle(ErrQuota).With(func(artefact any) {
+//		member := artefact.(nice.Member)
+//		log.Printf("%v, within %v", member.Err, member.Join)
+//	})
+//	panic(errors.Join(ErrInvalid, ErrQuota))
+type Member struct {
+	// Err is the matched member.
+	Err error
+	// Join is the full joined error, as panicked.
+	Join error
+}
+
+// Error returns the message of the matched member.
+func (m Member) Error() string {
+	return m.Err.Error()
+}
+
+// Unwrap returns the matched member, for errors.Is and errors.As.
+func (m Member) Unwrap() error {
+	return m.Err
+}
+
+// joinError is implemented by the errors of errors.Join and fmt.Errorf with several %w.
+type joinError interface {
+	error
+	Unwrap() []error
+}
+
+// matchMember returns the first member of the joined error matching the targets, depth first.
+func matchMember(targets dispatch.Targets, join joinError, trace tracer) (error, bool) {
+	for _, member := range join.Unwrap() {
+		if member == nil {
+			continue
+		}
+		if targets.Match(member, trace) {
+			return member, true
+		}
+		if nested, isJoin := member.(joinError); isJoin {
+			if matched, ok := matchMember(targets, nested, trace); ok {
+				return matched, true
+			}
+		}
+	}
+	return nil, false
+}
+
+var fanOutJoined atomic.Bool
+
+// FanOut enables or disables the fan-out of joined errors to the registered handlers.
+// Once enabled, a panic of a joined error is handled by every registered handler matching any of its members,
+// in registration order, instead of by the first one only.
+// So a batch operation panicking with an aggregate failure triggers the handler of each subsystem concerned.
+// The event returned and reported is of the first matched handler.
+//
+//	nice.FanOut(true)
+//	nice.Register(ErrPayment, notifyPayments)
+//	nice.Register(ErrInventory, notifyInventory)
+//	...
+//	panic(errors.Join(paymentErrs, inventoryErrs)) // notifies both
+func FanOut(enabled bool) {
+	fanOutJoined.Store(enabled)
+}
+
+// fanningOut tells whether the artefact is fanned out to all matching registrations.
+func fanningOut(artefact any) bool {
+	if !fanOutJoined.Load() {
+		return false
+	}
+	_, isJoin := artefact.(joinError)
+	return isJoin
+}
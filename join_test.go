@@ -0,0 +1,103 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type quotaError struct{ tenant string }
+
+func (e *quotaError) Error() string { return "quota exceeded for " + e.tenant }
+
+func TestJoinedErrors(t *testing.T) {
+	errInvalid := errors.New("invalid")
+	errQuota := &quotaError{tenant: "acme"}
+	join := errors.Join(errInvalid, errors.Join(fmt.Errorf("batch: %w", errInvalid), errQuota))
+
+	t.Run("Member", func(t *testing.T) {
+		var received any
+		func() {
+			defer Tackle(errInvalid).With(func(artefact any) { received = artefact })
+			panic(join)
+		}()
+		assert.Equal(t, Member{Err: errInvalid, Join: join}, received)
+		assert.ErrorIs(t, received.(error), errInvalid)
+	})
+
+	t.Run("Nested member", func(t *testing.T) {
+		var received any
+		func() {
+			defer Route(On(Type[*quotaError](), func(artefact any) { received = artefact })).Guard()
+			panic(join)
+		}()
+		assert.Equal(t, Member{Err: errQuota, Join: join}, received)
+	})
+
+	t.Run("Whole join", func(t *testing.T) {
+		var received any
+		func() {
+			defer Tackle().With(func(artefact any) { received = artefact })
+			panic(join)
+		}()
+		assert.Equal(t, join, received, "the join matching itself is passed as it is")
+	})
+
+	t.Run("No member", func(t *testing.T) {
+		assert.False(t, Tackle(errors.New("other")).matches(join))
+	})
+
+	t.Run("Registered", func(t *testing.T) {
+		cleanRegistry(t)
+		var received any
+		Register(errQuota, func(artefact any) { received = artefact })
+
+		event := Dispatch(context.Background(), join)
+
+		assert.True(t, event.Handled)
+		assert.Equal(t, join, event.Artefact)
+		assert.Equal(t, errQuota, event.Member)
+		assert.Equal(t, Member{Err: errQuota, Join: join}, received)
+	})
+}
+
+func TestFanOut(t *testing.T) {
+	cleanRegistry(t)
+	errPayment := errors.New("payment declined")
+	errInventory := errors.New("out of stock")
+	join := errors.Join(errPayment, errInventory)
+	var received []any
+	record := func(artefact any) { received = append(received, artefact) }
+	Register(errInventory, record)
+	Register(errors.New("unrelated"), record)
+	Register(errPayment, record, Named("payments"))
+
+	t.Run("Disabled", func(t *testing.T) {
+		received = nil
+		event := Dispatch(context.Background(), join)
+		assert.True(t, event.Handled)
+		assert.Equal(t, []any{Member{Err: errInventory, Join: join}}, received)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		FanOut(true)
+		t.Cleanup(func() { FanOut(false) })
+		received = nil
+
+		event := Dispatch(context.Background(), join)
+
+		assert.True(t, event.Handled)
+		assert.Equal(t, errInventory, event.Member, "the first matched handler decides the event")
+		assert.Equal(t, []any{
+			Member{Err: errInventory, Join: join},
+			Member{Err: errPayment, Join: join},
+		}, received)
+
+		received = nil
+		Dispatch(context.Background(), errPayment)
+		assert.Equal(t, []any{errPayment}, received, "other artefacts are handled once")
+	})
+}
@@ -0,0 +1,102 @@
+package nice
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// journalSocket is the socket of the native protocol of systemd-journald.
+var journalSocket = "/run/systemd/journal/socket"
+
+// JournalHandler returns an event handle func sending the event to systemd-journald,
+// with the structured fields PRIORITY, CODE_FILE, CODE_LINE and CODE_FUNC from the panic frame,
+// PANIC_TYPE, and the metadata as fields prefixed with PANIC_.
+// The identifier is recorded as SYSLOG_IDENTIFIER.
+// Register it with NeedsStack for the code fields.
+func JournalHandler(identifier string) func(event PanicEvent) {
+	return func(event PanicEvent) {
+		if event.SampledOut() {
+			return
+		}
+		conn, err := net.Dial("unixgram", journalSocket)
+		if err != nil {
+			logError(fmt.Errorf("connect journal: %w", err))
+			return
+		}
+		defer conn.Close()
+		if _, err := conn.Write(journalEntry(identifier, event)); err != nil {
+			logError(fmt.Errorf("send to journal: %w", err))
+		}
+	}
+}
+
+func journalEntry(identifier string, event PanicEvent) []byte {
+	var b bytes.Buffer
+	field := func(key, value string) {
+		if !strings.Contains(value, "\n") {
+			fmt.Fprintf(&b, "%s=%s\n", key, value)
+			return
+		}
+		// Values of multiple lines are prefixed with their length instead.
+		b.WriteString(key)
+		b.WriteByte('\n')
+		_ = binary.Write(&b, binary.LittleEndian, uint64(len(value)))
+		b.WriteString(value)
+		b.WriteByte('\n')
+	}
+
+	field("MESSAGE", "panic tackled: "+event.Message())
+	field("PRIORITY", strconv.Itoa(syslogPriority(event.Severity)))
+	if identifier != "" {
+		field("SYSLOG_IDENTIFIER", identifier)
+	}
+	field("PANIC_TYPE", event.Type())
+	field("PANIC_HANDLED", strconv.FormatBool(event.Handled))
+	if len(event.Stack) > 0 {
+		frame := event.Stack[0]
+		field("CODE_FILE", frame.File)
+		field("CODE_LINE", strconv.Itoa(frame.Line))
+		field("CODE_FUNC", frame.Function)
+	}
+	for _, k := range sortedKeys(event.Metadata) {
+		field("PANIC_"+journalFieldName(k), event.Metadata[k])
+	}
+	for _, k := range sortedKeys(event.Tags) {
+		field("PANIC_TAG_"+journalFieldName(k), event.Tags[k])
+	}
+	return b.Bytes()
+}
+
+// journalFieldName turns the metadata key into a field name of uppercase letters, digits and underscores.
+func journalFieldName(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+}
+
+// syslogPriority maps the severity to the syslog priority levels.
+func syslogPriority(s Severity) int {
+	switch s {
+	case SeverityDebug:
+		return 7
+	case SeverityInfo:
+		return 6
+	case SeverityWarning:
+		return 4
+	case SeverityCritical:
+		return 2
+	default:
+		return 3
+	}
+}
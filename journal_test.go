@@ -0,0 +1,48 @@
+package nice
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Skipf("unixgram unsupported: %v", err)
+	}
+	defer conn.Close()
+	saved := journalSocket
+	journalSocket = path
+	t.Cleanup(func() { journalSocket = saved })
+
+	JournalHandler("myd")(mockEvent())
+
+	buf := make([]byte, 4096)
+	n, _, err := conn.ReadFrom(buf)
+	if assert.NoError(t, err) {
+		entry := string(buf[:n])
+		assert.Contains(t, entry, "MESSAGE=panic tackled: mock error\n")
+		assert.Contains(t, entry, "PRIORITY=4\n")
+		assert.Contains(t, entry, "SYSLOG_IDENTIFIER=myd\n")
+		assert.Contains(t, entry, "CODE_FILE=/src/main.go\n")
+		assert.Contains(t, entry, "CODE_LINE=9\n")
+		assert.Contains(t, entry, "CODE_FUNC=main.run\n")
+		assert.Contains(t, entry, "PANIC_REQUEST=42\n")
+	}
+}
+
+func TestJournalEntryMultiline(t *testing.T) {
+	entry := journalEntry("", PanicEvent{Artefact: "two\nlines"})
+
+	var expected bytes.Buffer
+	expected.WriteString("MESSAGE\n")
+	_ = binary.Write(&expected, binary.LittleEndian, uint64(len("panic tackled: two\nlines")))
+	expected.WriteString("panic tackled: two\nlines\n")
+	assert.True(t, bytes.HasPrefix(entry, expected.Bytes()))
+}
@@ -0,0 +1,76 @@
+package nice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// JSONPanicError is the error of SafeMarshal and SafeUnmarshal for a panic while encoding or decoding,
+// typically of the MarshalJSON or UnmarshalJSON method of a third-party type.
+// It unwraps to the *PanicError.
+type JSONPanicError struct {
+	// Type which panicked, e.g. *money.Amount, as told by the stack,
+	// or the type of the value given to SafeMarshal or SafeUnmarshal.
+	Type string
+	// Panic recovered.
+	Panic *PanicError
+}
+
+func (e *JSONPanicError) Error() string {
+	return fmt.Sprintf("nice: json of %s: %s", e.Type, e.Panic.Error())
+}
+
+func (e *JSONPanicError) Unwrap() error {
+	return e.Panic
+}
+
+// SafeMarshal encodes v as json.Marshal, returning the panic of a MarshalJSON method
+// as a *JSONPanicError with the offending type recorded, rather than crashing.
+//
+//	data, err := nice.SafeMarshal(order)
+func SafeMarshal(v any) ([]byte, error) {
+	data, err, panicErr := ProtectResult2(func() ([]byte, error) { return json.Marshal(v) })
+	if panicErr != nil {
+		return nil, jsonPanic(panicErr, "MarshalJSON", v)
+	}
+	return data, err
+}
+
+// SafeUnmarshal decodes the data into v as json.Unmarshal, returning the panic of an UnmarshalJSON method
+// as a *JSONPanicError with the offending type recorded, rather than crashing.
+func SafeUnmarshal(data []byte, v any) error {
+	err, panicErr := ProtectResult(func() error { return json.Unmarshal(data, v) })
+	if panicErr != nil {
+		return jsonPanic(panicErr, "UnmarshalJSON", v)
+	}
+	return err
+}
+
+// jsonPanic returns the error of the panic, telling the offending type by the first frame of the method in the stack.
+func jsonPanic(err error, method string, v any) error {
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		// ErrProtectDepth.
+		return err
+	}
+	typ := fmt.Sprintf("%T", v)
+	for _, frame := range panicErr.Stack {
+		if name, found := strings.CutSuffix(frame.Function, "."+method); found {
+			typ = receiverType(name)
+			break
+		}
+	}
+	return &JSONPanicError{Type: typ, Panic: panicErr}
+}
+
+// receiverType formats the receiver of a method as in Go code, e.g. *money.Amount of example.com/money.(*Amount).
+func receiverType(name string) string {
+	pkg := name[strings.LastIndex(name, "/")+1:]
+	pkg, recv, _ := strings.Cut(pkg, ".")
+	if pointer, found := strings.CutPrefix(recv, "(*"); found {
+		return "*" + pkg + "." + strings.TrimSuffix(pointer, ")")
+	}
+	return pkg + "." + recv
+}
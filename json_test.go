@@ -0,0 +1,69 @@
+package nice
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errAmount = errors.New("no currency")
+
+type amount struct{ cents int }
+
+func (a *amount) MarshalJSON() ([]byte, error) {
+	if a.cents < 0 {
+		panic(errAmount)
+	}
+	return json.Marshal(a.cents)
+}
+
+func (a *amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		panic(errAmount)
+	}
+	return json.Unmarshal(data, &a.cents)
+}
+
+type order struct {
+	Total *amount `json:"total"`
+}
+
+func TestSafeMarshal(t *testing.T) {
+	data, err := SafeMarshal(order{Total: &amount{42}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"total":42}`, string(data))
+
+	_, err = SafeMarshal(order{Total: &amount{-1}})
+	var jsonErr *JSONPanicError
+	if assert.ErrorAs(t, err, &jsonErr) {
+		assert.Equal(t, "*nice.amount", jsonErr.Type, "the offending type, rather than the marshalled one")
+	}
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.ErrorIs(t, err, errAmount)
+	assert.EqualError(t, err, "nice: json of *nice.amount: panic: no currency")
+}
+
+func TestSafeUnmarshal(t *testing.T) {
+	var o order
+	assert.NoError(t, SafeUnmarshal([]byte(`{"total":42}`), &o))
+	assert.Equal(t, &amount{42}, o.Total)
+
+	var syntaxErr *json.SyntaxError
+	assert.ErrorAs(t, SafeUnmarshal([]byte(`{`), &o), &syntaxErr, "errors are returned as is")
+
+	err := SafeUnmarshal([]byte(`[null]`), &[]amount{})
+	var jsonErr *JSONPanicError
+	if assert.ErrorAs(t, err, &jsonErr) {
+		assert.Equal(t, "*nice.amount", jsonErr.Type)
+	}
+	assert.ErrorIs(t, err, errAmount)
+
+	t.Run("receiver type", func(t *testing.T) {
+		assert.Equal(t, "*money.Amount", receiverType("example.com/money.(*Amount)"))
+		assert.Equal(t, "money.Amount", receiverType("example.com/money.Amount"))
+		assert.Equal(t, "main.T", receiverType("main.T"))
+	})
+}
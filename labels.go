@@ -0,0 +1,141 @@
+package nice
+
+import (
+	"bufio"
+	"bytes"
+	"runtime"
+	"runtime/pprof"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Label matches panics on goroutines carrying the pprof label key=value,
+// e.g. as set by `pprof.Do(ctx, pprof.Labels("handler", "checkout"), ...)`,
+// so panics from a subsystem can be routed to its handlers without changing its code.
+// The artefact itself is not considered.
+//
+// The labels are looked up when the recovery point evaluates the target.
+// pprof.Do restores the previous labels when unwinding, so the recovery point
+// shall be inside the labelled function, or the labels shall be set on the goroutine
+// with pprof.SetGoroutineLabels.
+// The lookup takes a goroutine profile, which stops the world briefly.
+func Label(key, value string) Matcher {
+	return describedMatcher{
+		match: func(any) bool {
+			labels := goroutineLabels()
+			actual, found := labels[key]
+			return found && actual == value
+		},
+		description: "pprof label " + key + "=" + value,
+	}
+}
+
+// labelsMu serializes the lookup of goroutine labels,
+// so only one goroutine at a time has lookupLabels on its stack.
+var labelsMu sync.Mutex
+
+// goroutineLabels returns the pprof labels of the calling goroutine.
+// There is no API for reading them, so the goroutine is found in the goroutine profile
+// by the return PCs of its stack, which are unique while it is in lookupLabels.
+// It returns nil if the goroutine carries no label.
+func goroutineLabels() map[string]string {
+	labelsMu.Lock()
+	defer labelsMu.Unlock()
+	return lookupLabels()
+}
+
+//go:noinline
+func lookupLabels() map[string]string {
+	pcs := make([]uintptr, 32)
+	// From the return PC in goroutineLabels
+	pcs = pcs[:runtime.Callers(2, pcs)]
+
+	var profile bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&profile, 1); err != nil {
+		return nil
+	}
+
+	var (
+		found   map[string]string
+		matches int
+	)
+	scanner := bufio.NewScanner(&profile)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxCrashLine)
+	var stack []uintptr
+	for scanner.Scan() {
+		line := scanner.Text()
+		if _, addresses, isStack := strings.Cut(line, " @ "); isStack {
+			stack = parseAddresses(addresses)
+			if containsStack(stack, pcs) {
+				matches++
+				found = nil
+			}
+			continue
+		}
+		if labels, isLabels := strings.CutPrefix(line, "# labels: "); isLabels && containsStack(stack, pcs) {
+			found = parseLabels(labels)
+		}
+	}
+	if matches != 1 {
+		return nil
+	}
+	return found
+}
+
+func parseAddresses(addresses string) []uintptr {
+	fields := strings.Fields(addresses)
+	stack := make([]uintptr, 0, len(fields))
+	for _, f := range fields {
+		pc, err := strconv.ParseUint(f, 0, 64)
+		if err != nil {
+			return nil
+		}
+		stack = append(stack, uintptr(pc))
+	}
+	return stack
+}
+
+// containsStack tells whether the profiled stack contains the stack,
+// which may be truncated at the bottom in the profile.
+func containsStack(profiled, stack []uintptr) bool {
+	if len(stack) == 0 {
+		return false
+	}
+	i := slices.Index(profiled, stack[0])
+	if i < 0 {
+		return false
+	}
+	rest := profiled[i:]
+	n := min(len(rest), len(stack))
+	return slices.Equal(rest[:n], stack[:n])
+}
+
+// parseLabels parses e.g. `{"handler":"checkout", "tier":"web"}`.
+func parseLabels(labels string) map[string]string {
+	labels = strings.TrimSuffix(strings.TrimPrefix(labels, "{"), "}")
+	parsed := make(map[string]string)
+	for len(labels) > 0 {
+		key, rest, err := unquotePrefix(labels)
+		if err != nil || !strings.HasPrefix(rest, ":") {
+			return parsed
+		}
+		value, rest, err := unquotePrefix(rest[1:])
+		if err != nil {
+			return parsed
+		}
+		parsed[key] = value
+		labels = strings.TrimPrefix(rest, ", ")
+	}
+	return parsed
+}
+
+func unquotePrefix(s string) (unquoted, rest string, err error) {
+	quoted, err := strconv.QuotedPrefix(s)
+	if err != nil {
+		return "", s, err
+	}
+	unquoted, err = strconv.Unquote(quoted)
+	return unquoted, s[len(quoted):], err
+}
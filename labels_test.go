@@ -0,0 +1,57 @@
+package nice
+
+import (
+	"context"
+	"runtime/pprof"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabel(t *testing.T) {
+	t.Run("route by label", func(t *testing.T) {
+		cleanRegistry(t)
+		var routed []string
+		Register(Label("handler", "checkout"), func(any) { routed = append(routed, "checkout") })
+		Register(Label("handler", "search"), func(any) { routed = append(routed, "search") })
+
+		pprof.Do(context.Background(), pprof.Labels("handler", "search", "tier", "web"), func(context.Context) {
+			guarded("search failed")
+		})
+
+		assert.Equal(t, []string{"search"}, routed)
+	})
+
+	t.Run("concurrent goroutines", func(t *testing.T) {
+		var wg sync.WaitGroup
+		matched := make([]bool, 8)
+		for i := range matched {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				value := "odd"
+				if i%2 == 0 {
+					value = "even"
+				}
+				pprof.Do(context.Background(), pprof.Labels("parity", value), func(context.Context) {
+					matched[i] = Label("parity", value).Match(nil)
+				})
+			}()
+		}
+		wg.Wait()
+
+		for i, m := range matched {
+			assert.True(t, m, "goroutine %d", i)
+		}
+	})
+
+	t.Run("no label", func(t *testing.T) {
+		assert.False(t, Label("handler", "checkout").Match(nil))
+	})
+}
+
+func TestParseLabels(t *testing.T) {
+	assert.Equal(t, map[string]string{"handler": "checkout", "quote": `a"b, c`},
+		parseLabels(`{"handler":"checkout", "quote":"a\"b, c"}`))
+}
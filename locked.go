@@ -0,0 +1,16 @@
+package nice
+
+import (
+	"sync"
+)
+
+// Locked locks mu, runs fn and unlocks mu, even if fn panics.
+// A panic from fn is dispatched to the globally registered handlers after unlocking,
+// as by Guard, so a panic while holding the lock does not deadlock everything else.
+// Pass `rw.RLocker()` for read-locking a sync.RWMutex.
+func Locked(mu sync.Locker, fn func()) {
+	mu.Lock()
+	defer Guard()
+	defer mu.Unlock()
+	fn()
+}
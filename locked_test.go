@@ -0,0 +1,46 @@
+package nice
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocked(t *testing.T) {
+	t.Run("unlock and handle", func(t *testing.T) {
+		cleanRegistry(t)
+		var mu sync.Mutex
+		var lockedWhileHandling bool
+		Register(reflect.TypeFor[string](), func(any) {
+			lockedWhileHandling = !mu.TryLock()
+			if !lockedWhileHandling {
+				mu.Unlock()
+			}
+		})
+
+		Locked(&mu, func() { panic("while locked") })
+
+		assert.False(t, lockedWhileHandling, "The lock is released before handling.")
+		assert.True(t, mu.TryLock())
+	})
+
+	t.Run("unlock on fallthrough", func(t *testing.T) {
+		cleanRegistry(t)
+		var mu sync.RWMutex
+
+		assert.Panics(t, func() { Locked(mu.RLocker(), func() { panic(7) }) })
+		assert.True(t, mu.TryLock())
+	})
+
+	t.Run("not panicked", func(t *testing.T) {
+		var mu sync.Mutex
+		executed := false
+
+		Locked(&mu, func() { executed = !mu.TryLock() })
+
+		assert.True(t, executed)
+		assert.True(t, mu.TryLock())
+	})
+}
@@ -0,0 +1,159 @@
+package nice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Defaults of Main.
+const (
+	DefaultUnhandledExitCode = 2
+	DefaultHandledExitCode   = 1
+	DefaultFlushTimeout      = 5 * time.Second
+)
+
+// osExit is replaced in tests.
+var osExit = os.Exit
+
+// MainOption configures Main.
+type MainOption func(*mainConfig)
+
+type mainConfig struct {
+	unhandledExitCode int
+	handledExitCode   int
+	flushTimeout      time.Duration
+	output            io.Writer
+	signals           []os.Signal
+	exitCodes         []exitCode
+}
+
+type exitCode struct {
+	handler Handler
+	code    int
+}
+
+// UnhandledExitCode sets the exit code for panics no registered handler matches.
+// Defaults to 2, the same as the Go runtime.
+func UnhandledExitCode(code int) MainOption {
+	return func(c *mainConfig) { c.unhandledExitCode = code }
+}
+
+// HandledExitCode sets the exit code for panics handled by a registered handler.
+// Defaults to 1.
+func HandledExitCode(code int) MainOption {
+	return func(c *mainConfig) { c.handledExitCode = code }
+}
+
+// FlushTimeout bounds the time spent on flushing reporters before exit.
+func FlushTimeout(timeout time.Duration) MainOption {
+	return func(c *mainConfig) { c.flushTimeout = timeout }
+}
+
+// ReportTo sets where the crash report of unhandled panics is written.
+// Defaults to os.Stderr.
+func ReportTo(w io.Writer) MainOption {
+	return func(c *mainConfig) { c.output = w }
+}
+
+// Signals sets the signals which cancel the context given to run.
+// Defaults to os.Interrupt and SIGTERM.
+func Signals(signals ...os.Signal) MainOption {
+	return func(c *mainConfig) { c.signals = signals }
+}
+
+// ExitCode maps the target to the exit code of its panics,
+// so schedulers and scripts can tell the classes of failure apart by documented codes.
+// A mapped code applies whether or not the panic has been handled.
+// The target is anything accepted by Tackle.
+// If several targets match, the first one given applies.
+//
+//	nice.Main(run,
+//		nice.ExitCode(ErrBadInput, 64),
+//		nice.ExitCode(nice.Type[*os.PathError](), 66),
+//	)
+func ExitCode(target any, code int) MainOption {
+	return func(c *mainConfig) {
+		c.exitCodes = append(c.exitCodes, exitCode{handler: toHandler(target), code: code})
+	}
+}
+
+// Main is the entrypoint wrapper for CLI programs.
+// It calls run with a context which is cancelled on interrupt or termination signal,
+// dispatches a panic from run to the globally registered handlers,
+// flushes the reporters and exits the process with the code returned by run.
+// A panic not handled by any registered handler is written as a crash report
+// and exits with the unhandled exit code.
+//
+//	func main() {
+//		nice.Register(ErrConfig, logConfigError)
+//		nice.Main(run)
+//	}
+func Main(run func(ctx context.Context) int, opts ...MainOption) {
+	cfg := mainConfig{
+		unhandledExitCode: DefaultUnhandledExitCode,
+		handledExitCode:   DefaultHandledExitCode,
+		flushTimeout:      DefaultFlushTimeout,
+		output:            os.Stderr,
+		signals:           []os.Signal{os.Interrupt, syscall.SIGTERM},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), cfg.signals...)
+	code := runMain(ctx, run, cfg)
+	stop()
+
+	cfg.flush()
+	osExit(code)
+}
+
+// flush the reporters within the flush timeout.
+func (c mainConfig) flush() {
+	ctx, cancel := context.WithTimeout(context.Background(), c.flushTimeout)
+	defer cancel()
+	if err := Flush(ctx); err != nil {
+		fmt.Fprintf(c.output, "nice: flush reporters: %v\n", err)
+	}
+}
+
+func runMain(ctx context.Context, run func(ctx context.Context) int, cfg mainConfig) (code int) {
+	defer func() {
+		if RecoveryDisabled {
+			return
+		}
+		if artefact := recover(); artefact != nil {
+			event := dispatchRegistered(newEvent(artefact))
+			if event.Handled {
+				if reraising() {
+					// The panic raised again crashes the process before Main flushes.
+					cfg.flush()
+				}
+				reraise(artefact)
+				code = cfg.exitCode(artefact, cfg.handledExitCode)
+				return
+			}
+			unhandled(event, "Main")
+			fmt.Fprint(cfg.output, event.String())
+			code = cfg.exitCode(artefact, cfg.unhandledExitCode)
+		}
+	}()
+
+	return run(ctx)
+}
+
+// exitCode returns the code mapped by ExitCode for the artefact, or the fallback.
+func (c mainConfig) exitCode(artefact any, fallback int) int {
+	artefact = normalize(artefact)
+	for _, e := range c.exitCodes {
+		if _, matched := e.handler.resolveNormalized(artefact, nil); matched {
+			return e.code
+		}
+	}
+	return fallback
+}
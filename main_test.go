@@ -0,0 +1,119 @@
+package nice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mockExit(t *testing.T) *int {
+	t.Helper()
+	code := -1
+	exit := osExit
+	osExit = func(c int) { code = c }
+	t.Cleanup(func() { osExit = exit })
+	return &code
+}
+
+func TestMainEntrypoint(t *testing.T) {
+	t.Run("exit with returned code", func(t *testing.T) {
+		cleanRegistry(t)
+		code := mockExit(t)
+		reporter := &mockReporter{}
+		AddReporter(reporter)
+
+		Main(func(ctx context.Context) int {
+			assert.NoError(t, ctx.Err())
+			return 3
+		})
+
+		assert.Equal(t, 3, *code)
+		assert.True(t, reporter.flushed, "Reporters are flushed before exit.")
+	})
+
+	t.Run("handled panic", func(t *testing.T) {
+		cleanRegistry(t)
+		code := mockExit(t)
+		var output bytes.Buffer
+		Register(reflect.TypeFor[string](), func(any) {})
+
+		Main(func(context.Context) int {
+			panic("handled")
+		}, HandledExitCode(4), ReportTo(&output))
+
+		assert.Equal(t, 4, *code)
+		assert.Empty(t, output.String())
+	})
+
+	t.Run("handled panic in dry-run mode", func(t *testing.T) {
+		cleanRegistry(t)
+		enableDryRun(t)
+		mockExit(t)
+		reporter := &mockReporter{}
+		AddReporter(reporter)
+		Register(reflect.TypeFor[string](), func(any) {})
+
+		assert.PanicsWithValue(t, "observed", func() {
+			Main(func(context.Context) int {
+				panic("observed")
+			})
+		})
+		assert.True(t, reporter.flushed, "Reporters are flushed before the panic is raised again.")
+	})
+
+	t.Run("unhandled panic", func(t *testing.T) {
+		cleanRegistry(t)
+		code := mockExit(t)
+		var output bytes.Buffer
+
+		Main(func(context.Context) int {
+			panic("unhandled")
+		}, UnhandledExitCode(5), ReportTo(&output))
+
+		assert.Equal(t, 5, *code)
+		assert.Contains(t, output.String(), "panic: unhandled (string)\n")
+	})
+}
+
+type pathErrorMatcher struct{}
+
+func (pathErrorMatcher) Match(artefact any) bool {
+	_, matched := artefact.(*fs.PathError)
+	return matched
+}
+
+func TestExitCodes(t *testing.T) {
+	errBadInput := errors.New("bad input")
+	codes := []MainOption{
+		ExitCode(errBadInput, 64),
+		ExitCode(reflect.TypeFor[string](), 65),
+		ExitCode(pathErrorMatcher{}, 66),
+		ExitCode(MatcherFunc(func(artefact any) bool { return artefact == 7 }), 67),
+		ExitCode(reflect.TypeFor[error](), 70),
+		ExitCode(errBadInput, 71),
+	}
+	for artefact, expected := range map[any]int{
+		errBadInput: 64,
+		"message":   65,
+		&fs.PathError{Op: "open", Err: fs.ErrNotExist}: 66,
+		errors.New("other"):                            70,
+		7:                                              67,
+		8:                                              DefaultUnhandledExitCode,
+	} {
+		cleanRegistry(t)
+		code := mockExit(t)
+		Register(errBadInput, func(any) {})
+
+		Main(func(context.Context) int {
+			panic(artefact)
+		}, append(codes, ReportTo(io.Discard))...)
+
+		assert.Equal(t, expected, *code, "%v", artefact)
+	}
+}
@@ -0,0 +1,144 @@
+package nice
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/antonyho/nice/dispatch"
+)
+
+// Matcher is a target with custom matching logic, to be passed to Tackle.
+type Matcher = dispatch.Matcher
+
+// MatcherFunc adapts a function to Matcher.
+type MatcherFunc func(artefact any) bool
+
+// Match calls f(artefact).
+func (f MatcherFunc) Match(artefact any) bool {
+	return f(artefact)
+}
+
+// describedMatcher is a Matcher describing itself in the debug log.
+type describedMatcher struct {
+	match       func(artefact any) bool
+	description string
+}
+
+func (m describedMatcher) Match(artefact any) bool {
+	return m.match(artefact)
+}
+
+func (m describedMatcher) String() string {
+	return m.description
+}
+
+// TypeName matches artefacts by the name of their type, as in `reflect.Type.String()`,
+// e.g. "*os.PathError". The name "error" matches all types of error.
+// It is meant for targets declared in configuration, where no reflect.Type is at hand.
+func TypeName(name string) Matcher {
+	return describedMatcher{
+		match: func(artefact any) bool {
+			if artefact == nil {
+				return false
+			}
+			if _, isError := artefact.(error); isError && name == "error" {
+				return true
+			}
+			return reflect.TypeOf(artefact).String() == name
+		},
+		description: "type name " + name,
+	}
+}
+
+// Is matches error artefacts wrapping the target at any depth, as by errors.Is:
+// through fmt.Errorf("...: %w", target), custom Unwrap methods, and Is methods.
+// A bare error target matches the artefact equal to it only.
+//
+//	defer nice.Tackle(nice.Is(ErrNotFound)).With(respondNotFound)
+//	...
+//	panic(fmt.Errorf("load user %d: %w", id, ErrNotFound))
+func Is(target error) Matcher {
+	return describedMatcher{
+		match: func(artefact any) bool {
+			err, isError := artefact.(error)
+			return isError && errors.Is(err, target)
+		},
+		description: fmt.Sprintf("error wrapping %q (%T)", target.Error(), target),
+	}
+}
+
+// MessageMatches matches artefacts whose message matches the pattern.
+// The message of an error is its Error(), otherwise it is formatted as by fmt.Sprint.
+func MessageMatches(pattern *regexp.Regexp) Matcher {
+	return describedMatcher{
+		match: func(artefact any) bool {
+			return pattern.MatchString(PanicEvent{Artefact: artefact}.Message())
+		},
+		description: "message matches " + pattern.String(),
+	}
+}
+
+// AnyElement matches slice and array artefacts, such as []error or []any,
+// with any element matching the targets, accepted as by Tackle.
+// Frameworks panicking with collections of failures can still be routed by their elements.
+//
+//	defer nice.Tackle(nice.AnyElement(ErrTimeout)).With(retryBatch)
+func AnyElement(targets ...any) Matcher {
+	h := Tackle(targets...)
+	return describedMatcher{
+		match: func(artefact any) bool {
+			matched, count := matchElements(h, artefact)
+			return count > 0 && matched > 0
+		},
+		description: "any element of " + describeTargets(targets),
+	}
+}
+
+// AllElements matches slice and array artefacts with every element matching the targets,
+// accepted as by Tackle. Nil elements, e.g. of the items which succeeded, are ignored,
+// but at least one element shall match.
+func AllElements(targets ...any) Matcher {
+	h := Tackle(targets...)
+	return describedMatcher{
+		match: func(artefact any) bool {
+			matched, count := matchElements(h, artefact)
+			return count > 0 && matched == count
+		},
+		description: "all elements of " + describeTargets(targets),
+	}
+}
+
+// matchElements counts the non-nil elements of the artefact, and those matching the Handler.
+// The count is zero unless the artefact is a slice or an array.
+func matchElements(h Handler, artefact any) (matched, count int) {
+	v := reflect.ValueOf(artefact)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return 0, 0
+	}
+	for i := range v.Len() {
+		element := v.Index(i)
+		if element.Kind() == reflect.Interface && element.IsNil() {
+			continue
+		}
+		count++
+		if h.matches(element.Interface()) {
+			matched++
+		}
+	}
+	return matched, count
+}
+
+// describeTargets names the targets, or all errors if there is none.
+func describeTargets(targets []any) string {
+	if len(targets) == 0 {
+		return describeTarget(typeOfError)
+	}
+	names := make([]string, len(targets))
+	for i, t := range targets {
+		names[i] = describeTarget(t)
+	}
+	return strings.Join(names, ", ")
+}
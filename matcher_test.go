@@ -0,0 +1,115 @@
+package nice_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeName(t *testing.T) {
+	pathErr := &os.PathError{Op: "open", Path: "/x", Err: os.ErrNotExist}
+
+	assert.True(t, nice.TypeName("*fs.PathError").Match(pathErr))
+	assert.True(t, nice.TypeName("error").Match(pathErr))
+	assert.True(t, nice.TypeName("string").Match("message"))
+	assert.False(t, nice.TypeName("string").Match(7))
+	assert.False(t, nice.TypeName("error").Match("message"))
+	assert.False(t, nice.TypeName("string").Match(nil))
+}
+
+func TestMessageMatches(t *testing.T) {
+	matcher := nice.MessageMatches(regexp.MustCompile(`^timeout`))
+
+	assert.True(t, matcher.Match(errors.New("timeout after 5s")))
+	assert.True(t, matcher.Match("timeout"))
+	assert.False(t, matcher.Match(errors.New("connection timeout")))
+}
+
+func TestMatcherTarget(t *testing.T) {
+	mockHandler := &mockHandler{Executed: false}
+	defer assertExecuted(t, mockHandler)
+
+	defer nice.Tackle(nice.MatcherFunc(func(artefact any) bool {
+		code, isInt := artefact.(int)
+		return isInt && code >= 500
+	})).With(mockHandler.Handle)
+
+	panicFunc := func() {
+		panic(503)
+	}
+	panicFunc()
+}
+
+// wrappingError wraps by a custom Unwrap, instead of %w.
+type wrappingError struct {
+	err error
+}
+
+func (e wrappingError) Error() string { return "wrapping: " + e.err.Error() }
+
+func (e wrappingError) Unwrap() error { return e.err }
+
+func TestIs(t *testing.T) {
+	errNotFound := errors.New("not found")
+	matcher := nice.Is(errNotFound)
+
+	assert.True(t, matcher.Match(errNotFound))
+	assert.True(t, matcher.Match(fmt.Errorf("load user: %w", errNotFound)))
+	assert.True(t, matcher.Match(fmt.Errorf("handle: %w", fmt.Errorf("load user: %w", errNotFound))))
+	assert.True(t, matcher.Match(fmt.Errorf("handle: %w", wrappingError{fmt.Errorf("load user: %w", errNotFound)})))
+	assert.True(t, nice.Is(os.ErrNotExist).Match(&os.PathError{Op: "open", Path: "/x", Err: os.ErrNotExist}))
+	assert.False(t, matcher.Match(fmt.Errorf("load user: %v", errNotFound)))
+	assert.False(t, matcher.Match(errors.New("not found")))
+	assert.False(t, matcher.Match("not found"))
+	assert.Equal(t, `error wrapping "not found" (*errors.errorString)`, matcher.(fmt.Stringer).String())
+}
+
+func TestIsTarget(t *testing.T) {
+	errNotFound := errors.New("not found")
+	mockHandler := &mockHandler{Executed: false}
+	defer assertExecuted(t, mockHandler)
+
+	defer nice.Tackle(nice.Is(errNotFound)).With(mockHandler.Handle)
+
+	panic(fmt.Errorf("handle: %w", wrappingError{fmt.Errorf("load user: %w", errNotFound)}))
+}
+
+func TestErrorTargetIsExact(t *testing.T) {
+	errNotFound := errors.New("not found")
+	explanation := nice.Explain(fmt.Errorf("load user: %w", errNotFound), errNotFound)
+	assert.False(t, explanation.Matched, "a bare error target matches the equal artefact only")
+}
+
+func TestElements(t *testing.T) {
+	errTimeout := errors.New("timeout")
+	errRefused := errors.New("refused")
+
+	for name, tc := range map[string]struct {
+		artefact any
+		any, all bool
+	}{
+		"All errors match":  {[]error{errTimeout, nil, errTimeout}, true, true},
+		"Some errors match": {[]error{errRefused, errTimeout}, true, false},
+		"No error matches":  {[]error{errRefused}, false, false},
+		"Mixed elements":    {[]any{"skipped", errTimeout}, true, false},
+		"Array":             {[2]error{errTimeout, errTimeout}, true, true},
+		"Empty":             {[]error{}, false, false},
+		"Nil elements":      {[]error{nil, nil}, false, false},
+		"Not a slice":       {errTimeout, false, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.any, nice.AnyElement(errTimeout).Match(tc.artefact), "any element")
+			assert.Equal(t, tc.all, nice.AllElements(errTimeout).Match(tc.artefact), "all elements")
+		})
+	}
+
+	assert.True(t, nice.AllElements().Match([]error{errTimeout, errRefused}), "all errors by default")
+	assert.Equal(t, `any element of error "timeout" (*errors.errorString), type string`,
+		nice.AnyElement(errTimeout, reflect.TypeFor[string]()).(fmt.Stringer).String())
+}
@@ -0,0 +1,76 @@
+package nice
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Names of the metrics counted by this package.
+const (
+	MetricPanics    = "nice_panics_total"
+	MetricUnhandled = "nice_unhandled_total"
+	// MetricHandlerRuns counts the runs of the handlers and reporters by outcome, see ObserveHandlers.
+	MetricHandlerRuns = "nice_handler_runs_total"
+	// MetricHandlerDuration observes the durations of the handlers and reporters, in seconds.
+	MetricHandlerDuration = "nice_handler_duration_seconds"
+)
+
+// Outcomes of the handlers and reporters, as the "outcome" label of MetricHandlerRuns.
+const (
+	OutcomeOK       = "ok"
+	OutcomePanicked = "panicked"
+	OutcomeTimedOut = "timed_out"
+)
+
+// MetricsSink receives the metrics counted by this package,
+// to be adapted to the metrics system in use, e.g. Prometheus, StatsD or expvar.
+type MetricsSink interface {
+	Count(name string, labels map[string]string)
+}
+
+// MetricsObserver is a MetricsSink which also observes durations, e.g. into histograms.
+type MetricsObserver interface {
+	MetricsSink
+	Observe(name string, seconds float64, labels map[string]string)
+}
+
+type handlerMetrics struct {
+	sink MetricsSink
+}
+
+var observedHandlers atomic.Pointer[handlerMetrics]
+
+// ObserveHandlers counts every run of the registered handlers and reporters to the sink,
+// labelled by "kind" (handler or reporter), "handler" (its name, see Named) and "outcome",
+// so slow or broken reporters show up in dashboards before they cause incident-time pain.
+// A MetricsObserver also observes their durations. Passing nil stops the observation.
+//
+//	nice.ObserveHandlers(prometheusSink)
+func ObserveHandlers(sink MetricsSink) {
+	if sink == nil {
+		observedHandlers.Store(nil)
+		return
+	}
+	observedHandlers.Store(&handlerMetrics{sink: sink})
+}
+
+// observeHandler records the run of the handler or reporter started at start, if observed.
+func observeHandler(kind, name string, start time.Time, handlerPanic any, overrun bool) {
+	m := observedHandlers.Load()
+	if m == nil {
+		return
+	}
+	outcome := OutcomeOK
+	switch {
+	case overrun:
+		outcome = OutcomeTimedOut
+	case handlerPanic != nil:
+		outcome = OutcomePanicked
+	}
+	labels := map[string]string{"kind": kind, "handler": name}
+	if observer, observes := m.sink.(MetricsObserver); observes {
+		observer.Observe(MetricHandlerDuration, time.Since(start).Seconds(), labels)
+	}
+	labels = mergeTags(labels, map[string]string{"outcome": outcome})
+	m.sink.Count(MetricHandlerRuns, labels)
+}
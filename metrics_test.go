@@ -0,0 +1,60 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockObserver struct {
+	mockSink
+	observed []string
+}
+
+func (o *mockObserver) Observe(name string, seconds float64, labels map[string]string) {
+	o.observed = append(o.observed, name+" "+labels["kind"]+" "+labels["handler"])
+}
+
+func TestObserveHandlers(t *testing.T) {
+	cleanRegistry(t)
+	sink := &mockObserver{}
+	ObserveHandlers(sink)
+	t.Cleanup(func() { ObserveHandlers(nil) })
+	OnHandlerPanic(Suppress)
+	t.Cleanup(func() { OnHandlerPanic(PropagateOriginal) })
+
+	errSlow := errors.New("slow")
+	errBuggy := errors.New("buggy")
+	release := make(chan struct{})
+	defer close(release)
+	Register(errSlow, func(any) { <-release }, Named("slow"), Deadline(time.Millisecond))
+	Register(errSlow, func(any) {}, Named("fallback"))
+	Register(errBuggy, func(any) { panic("bug") }, Named("buggy"))
+	AddReporter(ReporterFunc(func(PanicEvent) {}))
+
+	Dispatch(context.Background(), errSlow)
+	Dispatch(context.Background(), errBuggy)
+
+	outcomes := make([]string, len(sink.labels))
+	for i, labels := range sink.labels {
+		outcomes[i] = labels["kind"] + " " + labels["handler"] + " " + labels["outcome"]
+	}
+	assert.Equal(t, []string{
+		"handler slow timed_out",
+		"handler fallback ok",
+		"reporter reporter #0 ok",
+		"handler buggy panicked",
+		"reporter reporter #0 ok",
+	}, outcomes)
+	assert.Equal(t, []string{
+		MetricHandlerDuration + " handler slow",
+		MetricHandlerDuration + " handler fallback",
+		MetricHandlerDuration + " reporter reporter #0",
+		MetricHandlerDuration + " handler buggy",
+		MetricHandlerDuration + " reporter reporter #0",
+	}, sink.observed)
+	assert.Equal(t, MetricHandlerRuns, sink.counts[0])
+}
@@ -0,0 +1,54 @@
+package nice
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// EnvMode is the environment variable setting the Mode at startup by its name,
+// e.g. `NICE_MODE=development`.
+const EnvMode = "NICE_MODE"
+
+// Mode of the recovery points, set by SetMode.
+type Mode int32
+
+// Modes of the recovery points.
+const (
+	// Production recovers the handled panics, as by the policy of every recovery point.
+	Production Mode = iota
+	// Development raises the handled panics again once the handlers ran, as SetDryRun does,
+	// so crashes stay visible while developing.
+	Development
+)
+
+var modeNames = []string{
+	Production:  "production",
+	Development: "development",
+}
+
+func (m Mode) String() string {
+	if m < 0 || int(m) >= len(modeNames) {
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+	return modeNames[m]
+}
+
+var mode atomic.Int32
+
+func init() {
+	for i, name := range modeNames {
+		if name == os.Getenv(EnvMode) {
+			SetMode(Mode(i))
+		}
+	}
+}
+
+// SetMode sets the mode of the recovery points, so one codebase serves both environments without build tags.
+//
+//	if *dev {
+//		nice.SetMode(nice.Development)
+//	}
+func SetMode(m Mode) {
+	mode.Store(int32(m))
+}
@@ -0,0 +1,41 @@
+package nice
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMode(t *testing.T) {
+	t.Cleanup(func() { SetMode(Production) })
+	mockErr := errors.New("visible")
+
+	SetMode(Development)
+	var observed any
+	assert.PanicsWithValue(t, mockErr, func() {
+		defer Tackle(mockErr).With(func(artefact any) { observed = artefact })
+		panic(mockErr)
+	}, "Development raises the panic again")
+	assert.Equal(t, mockErr, observed, "after the handlers ran")
+
+	cleanRegistry(t)
+	reporter := &mockReporter{}
+	AddReporter(reporter)
+	Register(reflect.TypeFor[error](), func(any) {})
+	assert.PanicsWithValue(t, mockErr, func() {
+		defer Guard()
+		panic(mockErr)
+	})
+	assert.Len(t, reporter.events, 1)
+
+	SetMode(Production)
+	assert.NotPanics(t, func() {
+		defer Tackle(mockErr).With(func(any) {})
+		panic(mockErr)
+	})
+
+	assert.Equal(t, "development", Development.String())
+	assert.Equal(t, "Mode(7)", Mode(7).String())
+}
@@ -0,0 +1,80 @@
+package nice
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+// Module is a bundle of handlers and reporters shipped by a library
+// for the panic types of its own.
+// Applications opt into it with a single Use.
+//
+//	// In the library.
+//	var Handlers = nice.ModuleFunc(func(r *nice.Registry) {
+//		r.Register(nice.Type[*ParseError](), logParseError, nice.Named("mylib.parse"))
+//	})
+//
+//	// In the application.
+//	nice.Use(mylib.Handlers)
+type Module interface {
+	Install(r *Registry)
+}
+
+// ModuleFunc adapts a func to Module.
+type ModuleFunc func(r *Registry)
+
+// Install calls f(r).
+func (f ModuleFunc) Install(r *Registry) {
+	f(r)
+}
+
+// Registry collects what a Module installs.
+// It is committed to the global registry once every module given to Use has been installed.
+type Registry struct {
+	registrations []registration
+	reporters     []reporterEntry
+}
+
+// Register the handle func for the target, as the package level Register.
+func (r *Registry) Register(target any, handle func(artefact any), opts ...RegisterOption) {
+	r.RegisterEvent(target, handleArtefact(handle), opts...)
+}
+
+// RegisterEvent registers the handle func for the target, as the package level RegisterEvent.
+func (r *Registry) RegisterEvent(target any, handle func(event PanicEvent), opts ...RegisterOption) {
+	r.registrations = append(r.registrations, registration{
+		registerOptions: newRegisterOptions(opts),
+		handler:         toHandler(target),
+		handle:          handle,
+	})
+}
+
+// AddReporter adds the reporter, as the package level AddReporter.
+func (r *Registry) AddReporter(reporter Reporter, opts ...RegisterOption) {
+	r.reporters = append(r.reporters, reporterEntry{
+		registerOptions: newRegisterOptions(opts),
+		reporter:        reporter,
+	})
+}
+
+// Use installs the modules into the global registry, in order.
+// Everything the modules install is committed at once,
+// so concurrent dispatch never sees a partially installed module.
+func Use(modules ...Module) {
+	var r Registry
+	for _, m := range modules {
+		m.Install(&r)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	for i := range r.registrations {
+		registry.lastID++
+		r.registrations[i].id = registry.lastID
+		r.registrations[i].matched = new(atomic.Uint64)
+	}
+	updateRegistry(func(s *registrySnapshot) {
+		s.registrations = slices.Concat(s.registrations, r.registrations)
+		s.reporters = slices.Concat(s.reporters, r.reporters)
+	})
+}
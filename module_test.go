@@ -0,0 +1,31 @@
+package nice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type moduleError struct{}
+
+func (moduleError) Error() string { return "module" }
+
+func TestUse(t *testing.T) {
+	cleanRegistry(t)
+	reporter := &mockReporter{}
+	var handled any
+	Use(ModuleFunc(func(r *Registry) {
+		r.Register(Type[moduleError](), func(artefact any) { handled = artefact }, Named("lib.module"))
+		r.AddReporter(reporter)
+	}))
+
+	assert.NotPanics(t, func() { guarded(moduleError{}) })
+	assert.Equal(t, moduleError{}, handled)
+	assert.Len(t, reporter.events, 1)
+	assert.Panics(t, func() { guarded(errors.New("other")) }, "Only the module's types are handled.")
+
+	registrations := loadRegistry().registrations
+	assert.Equal(t, "lib.module", registrations[0].name)
+	assert.NotZero(t, registrations[0].id)
+}
@@ -0,0 +1,80 @@
+package nice
+
+import (
+	"context"
+	"slices"
+)
+
+// Namespace is a scope of the global registry for a subsystem,
+// letting large monoliths keep the panic policies of their teams isolated under one dispatcher.
+// Handlers registered in a namespace are consulted only for panics recovered in it,
+// ahead of the handlers registered outside of any namespace.
+// Their names are prefixed with the namespace and a dot for Reload,
+// and the events recovered in the namespace are tagged with it as "namespace".
+//
+//	payments := nice.Namespace("payments")
+//	payments.Register(ErrDeclined, alertPayments, nice.Named("declined"))
+//
+//	func charge() {
+//		defer payments.Guard()
+//		...
+//	}
+type Namespace string
+
+type namespaceKey struct{}
+
+// Register the handle func for the target in the namespace, as the package level Register.
+func (ns Namespace) Register(target any, handle func(artefact any), opts ...RegisterOption) {
+	ns.RegisterEvent(target, handleArtefact(handle), opts...)
+}
+
+// RegisterEvent registers the handle func for the target in the namespace, as the package level RegisterEvent.
+func (ns Namespace) RegisterEvent(target any, handle func(event PanicEvent), opts ...RegisterOption) {
+	options := newRegisterOptions(opts)
+	if options.name != "" {
+		options.name = string(ns) + "." + options.name
+	}
+	register(registration{
+		registerOptions: options,
+		handler:         toHandler(target),
+		handle:          handle,
+		namespace:       string(ns),
+	})
+}
+
+// Context returns a copy of ctx in the namespace, for GuardContext, Dispatch and nicehttp.Middleware.
+func (ns Namespace) Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, string(ns))
+}
+
+// Guard recovers panic and dispatches the artefact in the namespace, as the package level Guard.
+// It shall be deferred directly.
+func (ns Namespace) Guard() {
+	if RecoveryDisabled {
+		return
+	}
+	if artefact := recover(); artefact != nil {
+		Fallthrough(recovered(ns.Context(context.Background()), newEvent(artefact), "Guard"))
+	}
+}
+
+func namespaceFromContext(ctx context.Context) string {
+	ns, _ := ctx.Value(namespaceKey{}).(string)
+	return ns
+}
+
+// inNamespaceFirst orders the registrations of the namespace ahead of the others, keeping their order.
+func inNamespaceFirst(namespace string, registrations []registration) []registration {
+	ordered := make([]registration, 0, len(registrations))
+	for _, r := range registrations {
+		if r.namespace == namespace {
+			ordered = append(ordered, r)
+		}
+	}
+	for _, r := range registrations {
+		if r.namespace != namespace {
+			ordered = append(ordered, r)
+		}
+	}
+	return slices.Clip(ordered)
+}
@@ -0,0 +1,45 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespace(t *testing.T) {
+	cleanRegistry(t)
+	reporter := &mockReporter{}
+	AddReporter(reporter)
+	var executed []string
+	Register(Tackle(), func(any) { executed = append(executed, "global") })
+	payments := Namespace("payments")
+	var tags map[string]string
+	payments.RegisterEvent(reflect.TypeFor[string](), func(event PanicEvent) {
+		executed = append(executed, "payments")
+		tags = event.Tags
+	}, Named("declined"))
+	Namespace("search").Register(reflect.TypeFor[string](), func(any) { executed = append(executed, "search") })
+
+	func() {
+		defer payments.Guard()
+		panic("declined")
+	}()
+	assert.Equal(t, []string{"payments"}, executed, "The namespace is consulted ahead of the global handlers.")
+	assert.Equal(t, map[string]string{"namespace": "payments"}, tags, "The handlers see the namespace tag.")
+	assert.Equal(t, map[string]string{"namespace": "payments"}, reporter.events[0].Tags)
+
+	executed = nil
+	assert.Panics(t, func() { guarded("outside") }, "Handlers in namespaces are isolated.")
+	assert.Nil(t, executed)
+
+	executed = nil
+	Dispatch(payments.Context(context.Background()), errFromNamespace)
+	assert.Equal(t, []string{"global"}, executed, "Global handlers apply in any namespace.")
+
+	assert.Equal(t, "payments.declined", loadRegistry().registrations[1].name)
+}
+
+var errFromNamespace = errors.New("from namespace")
@@ -5,75 +5,208 @@ package nice
 
 import (
 	"reflect"
-	"slices"
+
+	"github.com/antonyho/nice/dispatch"
 )
 
 // Handler for the given artefact and error types
 type Handler struct {
 	artefactTypes []reflect.Type
 	errorTypes    []error
+	// errorIndex looks up errorTypes in one step when there are many of them.
+	errorIndex map[error]struct{}
+	matchers   []Matcher
+	// tags of the events handled by the Handler once registered, see Tags.
+	tags map[string]string
+	// before runs right after recover, ahead of matching and handling.
+	before []func()
+	// finally runs after With, whether or not a panic occurred.
+	finally []func()
 }
 
-// With takes a handle function from parameter
-// and call the function while panic artfact type matches.
+// With takes handle functions from parameter
+// and call them in order while panic artfact type matches,
+// so simple chains such as log, then report, then cleanup need no combinator.
 // The handle func does not catch panic from other level's goroutine.
-func (h Handler) With(handle func(artefact any)) {
+//
+//	defer nice.Tackle(ErrSync).With(logError, reportError, rollback)
+func (h Handler) With(handles ...func(artefact any)) {
+	if len(h.finally) > 0 {
+		defer h.runFinally()
+	}
+	if RecoveryDisabled {
+		return
+	}
 	if lastMsg := recover(); lastMsg != nil {
-		switch asserted := lastMsg.(type) {
-		case error:
-			typeOfError := reflect.TypeFor[error]()
-			// Handle general error registered
-			if slices.Contains(h.artefactTypes, typeOfError) {
-				handle(lastMsg)
-				return
+		h.tackle(lastMsg, handles, nil)
+	}
+}
+
+// WithFlag works as With, and sets the flag when the panic has been handled,
+// so the logic after the deferred call in outer frames can branch on it,
+// e.g. to retry or to take an alternative path.
+//
+//	var handled bool
+//	func() {
+//		defer nice.Tackle(ErrUnavailable).WithFlag(&handled, logError)
+//		callPrimary()
+//	}()
+//	if handled {
+//		callSecondary()
+//	}
+func (h Handler) WithFlag(handled *bool, handles ...func(artefact any)) {
+	if len(h.finally) > 0 {
+		defer h.runFinally()
+	}
+	if RecoveryDisabled {
+		return
+	}
+	if lastMsg := recover(); lastMsg != nil {
+		h.tackle(lastMsg, handles, handled)
+	}
+}
+
+// Finally returns a Handler which runs fn after With,
+// whether or not a panic occurred, so cleanup shared between the success and panic paths
+// lives next to the panic policy.
+// It runs after the handle func, or before the panic falls through.
+// Multiple fn run in the order they are given.
+//
+//	defer nice.Tackle(ErrRollback).Finally(tx.Close).With(logRollback)
+func (h Handler) Finally(fn ...func()) Handler {
+	h.finally = append(h.finally[:len(h.finally):len(h.finally)], fn...)
+	return h
+}
+
+func (h Handler) runFinally() {
+	for _, fn := range h.finally {
+		fn()
+	}
+}
+
+// tackle the recovered artefact, or let it fall through.
+func (h Handler) tackle(lastMsg any, handles []func(artefact any), handled *bool) {
+	for _, fn := range h.before {
+		fn()
+	}
+
+	logger := debugLogger.Load()
+	debugRecovered(logger, "With", lastMsg)
+	if resolved, matched := h.resolve(lastMsg, debugTracer(logger, "With")); matched {
+		if handled != nil {
+			*handled = true
+		}
+		for _, handle := range handles {
+			if logger != nil {
+				debugOutcome(logger, "With", funcName(handle), true)
 			}
-			// Handle specific error registered
-			if slices.Contains(h.errorTypes, asserted) {
-				handle(lastMsg)
-				return
+			if handlerPanic := runHandle(handle, resolved); handlerPanic != nil {
+				handlerPanicked(lastMsg, handlerPanic)
 			}
-		default:
-			typeOfLastMsg := reflect.TypeOf(lastMsg)
-			if slices.Contains(h.artefactTypes, typeOfLastMsg) {
-				handle(lastMsg)
-				return
+		}
+		reraise(lastMsg)
+		return
+	}
+	debugOutcome(logger, "With", "", false)
+	if observingUnhandled() {
+		unhandled(stacked(newEvent(lastMsg)), "With")
+	}
+
+	// Fallthrough if not tackled
+	panic(lastMsg) // This will ruin the call stack. Need a new solution.
+}
+
+// matches tells whether the artefact is a registered target of the Handler.
+func (h Handler) matches(artefact any) bool {
+	return h.match(artefact, nil)
+}
+
+// match the artefact against the targets in order,
+// reporting every evaluated target to the tracer if not nil.
+// A joined error matches if any of its members does.
+func (h Handler) match(artefact any, trace tracer) bool {
+	_, matched := h.resolve(artefact, trace)
+	return matched
+}
+
+// resolve matches the artefact normalized by SetNormalizer as match does, returning what the handle func receives:
+// the artefact itself, the Member matched of a joined error, or the value unwrapped by PanicWrapper.
+func (h Handler) resolve(artefact any, trace tracer) (any, bool) {
+	return h.resolveNormalized(normalize(artefact), trace)
+}
+
+// resolveNormalized resolves the artefact already normalized, for matching it against many handlers.
+func (h Handler) resolveNormalized(artefact any, trace tracer) (any, bool) {
+	targets := h.targets()
+	for range maxUnwrapDepth {
+		if targets.Match(artefact, trace) {
+			return artefact, true
+		}
+		if join, isJoin := artefact.(joinError); isJoin {
+			if member, matched := matchMember(targets, join, trace); matched {
+				return Member{Err: member, Join: join}, true
 			}
 		}
+		wrapper, isWrapper := artefact.(PanicWrapper)
+		if !isWrapper {
+			break
+		}
+		artefact = wrapper.UnwrapPanic()
+	}
+	return nil, false
+}
 
-		// Fallthrough if not tackled
-		panic(lastMsg) // This will ruin the call stack. Need a new solution.
+// targets of the Handler for the dispatch engine.
+func (h Handler) targets() dispatch.Targets {
+	return dispatch.Targets{
+		Types:    h.artefactTypes,
+		Errors:   h.errorTypes,
+		Index:    h.errorIndex,
+		Matchers: h.matchers,
 	}
 }
 
+var typeOfError = reflect.TypeFor[error]()
+
+// genericTargets is shared by every Handler tackling all errors. It is never appended.
+var genericTargets = []reflect.Type{typeOfError}
+
+// errorIndexThreshold is the number of error targets from which they are indexed.
+const errorIndexThreshold = dispatch.IndexThreshold
+
 // Tackle panic with provided targets type
 // returns a Handler, which shall be pairly used With().
 // Pass exact error to the `targets`,
 // if you want to handle particular type of error.
 // Passsing `reflect.TypeFor[error]()` registers all types of error
 // to be handled by the handle function.
+// Pass a Matcher for custom matching logic.
 // Not passing any parameter to targets will assume generic error
 // would be handled.
 func Tackle(targets ...any) Handler {
-	artefactTypes := make([]reflect.Type, 0)
-	errorTypes := make([]error, 0)
-
 	if len(targets) == 0 {
 		return Handler{
-			artefactTypes: []reflect.Type{reflect.TypeFor[error]()},
-			errorTypes:    errorTypes,
+			artefactTypes: genericTargets,
+			errorTypes:    make([]error, 0),
 		}
 	}
 
+	var tags map[string]string
 	for _, t := range targets {
-		if errorType, matched := t.(error); matched {
-			errorTypes = append(errorTypes, errorType)
-			continue
+		if opt, matched := t.(RegisterOption); matched {
+			var o registerOptions
+			opt(&o)
+			tags = mergeTags(tags, o.tags)
 		}
-		if artefactType, matched := t.(reflect.Type); matched {
-			artefactTypes = append(artefactTypes, artefactType)
-		}
-		// Unknown target is being ignored and is being discarded
 	}
+	// Unknown targets are ignored and discarded
+	compiled := dispatch.Compile(targets...)
 
-	return Handler{artefactTypes: artefactTypes, errorTypes: errorTypes}
+	return Handler{
+		artefactTypes: compiled.Types,
+		errorTypes:    compiled.Errors,
+		errorIndex:    compiled.Index,
+		matchers:      compiled.Matchers,
+		tags:          tags,
+	}
 }
@@ -0,0 +1,82 @@
+package nicehttp
+
+import (
+	"cmp"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/antonyho/nice"
+)
+
+// DebugHandler renders the recent events kept by nice.KeepRecent, newest first,
+// with their stacks and the counts per fingerprint, for quick production triage.
+// It responds with JSON if the format query parameter is "json",
+// or if the request accepts application/json, and with HTML otherwise.
+// Mount it next to net/http/pprof, behind the same access control:
+//
+//	nice.KeepRecent(100)
+//	mux.Handle("/debug/panics", nicehttp.DebugHandler())
+func DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events := nice.Recent(0)
+		slices.Reverse(events)
+		page := debugPage{Counts: countFingerprints(events), Events: events}
+
+		if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(page)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = debugTemplate.Execute(w, page)
+	})
+}
+
+type debugPage struct {
+	Counts []fingerprintCount `json:"counts"`
+	Events []nice.PanicEvent  `json:"events"`
+}
+
+type fingerprintCount struct {
+	Fingerprint string `json:"fingerprint"`
+	Count       int    `json:"count"`
+}
+
+// countFingerprints counts the events per fingerprint, most frequent first.
+func countFingerprints(events []nice.PanicEvent) []fingerprintCount {
+	index := make(map[string]int)
+	var counts []fingerprintCount
+	for _, e := range events {
+		f := nice.Fingerprint(e)
+		i, seen := index[f]
+		if !seen {
+			i = len(counts)
+			index[f] = i
+			counts = append(counts, fingerprintCount{Fingerprint: f})
+		}
+		counts[i].Count++
+	}
+	slices.SortStableFunc(counts, func(a, b fingerprintCount) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	return counts
+}
+
+var debugTemplate = template.Must(template.New("panics").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Recent panics</title></head>
+<body>
+<h1>Recent panics</h1>
+{{if not .Events}}<p>No panic has been kept. Enable it with nice.KeepRecent.</p>{{end}}
+{{with .Counts}}<table>
+<tr><th>Count</th><th>Fingerprint</th></tr>
+{{range .}}<tr><td>{{.Count}}</td><td>{{.Fingerprint}}</td></tr>
+{{end}}</table>{{end}}
+{{range .Events}}<h2>{{.Time.Format "2006-01-02T15:04:05.000Z07:00"}}</h2>
+<pre>{{.String}}</pre>
+{{end}}</body>
+</html>
+`))
@@ -0,0 +1,65 @@
+package nicehttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/nicehttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugHandler(t *testing.T) {
+	nice.KeepRecent(10)
+	t.Cleanup(func() { nice.KeepRecent(0) })
+	ctx := nice.WithHandlers(context.Background(), nice.On(reflect.TypeFor[debugArtefact](), func(any) {}))
+	for _, message := range []debugArtefact{"<b>often</b>", "<b>often</b>", "seldom"} {
+		func() {
+			defer nice.GuardContext(ctx)
+			panic(message)
+		}()
+	}
+
+	t.Run("json", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		nicehttp.DebugHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/panics?format=json", nil))
+
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var page struct {
+			Counts []struct {
+				Fingerprint string `json:"fingerprint"`
+				Count       int    `json:"count"`
+			} `json:"counts"`
+			Events []struct {
+				Message string `json:"message"`
+				Stack   []any  `json:"stack"`
+			} `json:"events"`
+		}
+		if assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page)) {
+			if assert.Len(t, page.Counts, 2) {
+				assert.Equal(t, "nicehttp_test.debugArtefact: <b>often</b> at github.com/antonyho/nice/nicehttp_test.TestDebugHandler.func3",
+					page.Counts[0].Fingerprint, "grouped by nice.Fingerprint")
+				assert.Equal(t, 2, page.Counts[0].Count)
+			}
+			if assert.Len(t, page.Events, 3) {
+				assert.Equal(t, "seldom", page.Events[0].Message, "Newest event comes first.")
+				assert.NotEmpty(t, page.Events[0].Stack)
+			}
+		}
+	})
+
+	t.Run("html", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		nicehttp.DebugHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/panics", nil))
+
+		assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, rec.Body.String(), "&lt;b&gt;often&lt;/b&gt;")
+		assert.NotContains(t, rec.Body.String(), "<b>often</b>", "Artefacts are escaped.")
+	})
+}
+
+type debugArtefact string
@@ -0,0 +1,246 @@
+/*
+Package nicehttp provides the HTTP integration of nice.
+*/
+package nicehttp
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/dispatch"
+)
+
+// Redacted replaces the values of redacted headers in the events.
+const Redacted = "[REDACTED]"
+
+// DefaultCorrelationHeader carries the correlation ID of the requests, unless overridden by CorrelationHeader.
+const DefaultCorrelationHeader = "X-Request-Id"
+
+// DefaultRedactedHeaders are redacted unless overridden by RedactHeaders.
+var DefaultRedactedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie"}
+
+// Option configures the middleware of NewMiddleware.
+type Option func(*config)
+
+type config struct {
+	headers           []string
+	redacted          []string
+	bodyLimit         int
+	redactBody        func(body []byte) []byte
+	correlationHeader string
+	quotaPanics       int
+	quotaWindow       time.Duration
+	quotaStatus       int
+	clientKey         func(r *http.Request) string
+	statuses          dispatch.Engine[int]
+	panicTrailer      string
+	abortPartial      bool
+}
+
+// CorrelationHeader sets the header carrying the correlation ID, instead of DefaultCorrelationHeader.
+func CorrelationHeader(name string) Option {
+	return func(c *config) { c.correlationHeader = name }
+}
+
+// CaptureHeaders records the values of the request headers in the event metadata,
+// as http_header_<name>, e.g. http_header_user_agent.
+func CaptureHeaders(names ...string) Option {
+	return func(c *config) { c.headers = append(c.headers, names...) }
+}
+
+// RedactHeaders sets the headers whose values are recorded as Redacted when captured,
+// instead of DefaultRedactedHeaders.
+func RedactHeaders(names ...string) Option {
+	return func(c *config) { c.redacted = names }
+}
+
+// CaptureBody records up to limit bytes of the request body read by the handler
+// in the event metadata, as http_body, so requests causing 500 can be reproduced.
+// A body over the limit is truncated, and recorded so as http_body_truncated.
+func CaptureBody(limit int) Option {
+	return func(c *config) { c.bodyLimit = limit }
+}
+
+// RedactBody transforms the captured body before it is recorded, e.g. to mask credentials.
+func RedactBody(redact func(body []byte) []byte) Option {
+	return func(c *config) { c.redactBody = redact }
+}
+
+// Middleware recovers panics from the next handler, and dispatches them
+// to the handlers carried by the request context, see nice.WithHandlers,
+// then to the globally registered handlers.
+// The method and path of the request are recorded in the event metadata
+// as http_method and http_path.
+// Every request is given a correlation ID, taken from the request header if present,
+// which is set in the response header, recorded as correlation_id in the event metadata,
+// and available to the next handler by CorrelationID.
+// The W3C trace context of the traceparent header is recorded as trace_id and span_id,
+// see nice.WithTraceParent.
+// A handled panic is responded with 500 Internal Server Error, or the status given by Statuses, quoting the correlation ID,
+// so an error reported by a customer can be matched with the exact crash report.
+// A panic after the response started, e.g. while streaming, is not responded over:
+// it is recorded as http_partial_response, with http_status and http_written_bytes,
+// and the response is ended as by PanicTrailer and AbortPartial.
+// An unhandled panic falls through to net/http.
+func Middleware(next http.Handler) http.Handler {
+	return NewMiddleware()(next)
+}
+
+// NewMiddleware returns the middleware configured by the options, which works as Middleware.
+// Routes can be wrapped with their own options, e.g. to capture the body of some only.
+//
+//	capture := nicehttp.NewMiddleware(nicehttp.CaptureHeaders("User-Agent"), nicehttp.CaptureBody(4096))
+//	mux.Handle("/orders", capture(orders))
+func NewMiddleware(opts ...Option) func(next http.Handler) http.Handler {
+	cfg := config{
+		redacted:          DefaultRedactedHeaders,
+		correlationHeader: DefaultCorrelationHeader,
+		quotaStatus:       http.StatusTooManyRequests,
+		clientKey:         remoteIP,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	var q *quota
+	if cfg.quotaPanics > 0 {
+		q = &quota{panics: cfg.quotaPanics, window: cfg.quotaWindow}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(cfg.correlationHeader)
+			if id == "" {
+				id = newCorrelationID()
+			}
+			w.Header().Set(cfg.correlationHeader, id)
+			var client string
+			if q != nil {
+				client = cfg.clientKey(r)
+				if retryAfter := q.retryAfter(client); retryAfter > 0 {
+					q.reject(w, cfg.quotaStatus, retryAfter)
+					return
+				}
+			}
+			ctx := context.WithValue(r.Context(), correlationKey{}, id)
+			if header := r.Header.Get("Traceparent"); header != "" {
+				// A malformed traceparent is ignored, as by W3C Trace Context.
+				ctx, _ = nice.WithTraceParent(ctx, header)
+			}
+			r = r.WithContext(ctx)
+
+			var body *capturedBody
+			if cfg.bodyLimit > 0 && r.Body != nil {
+				body = &capturedBody{ReadCloser: r.Body, limit: cfg.bodyLimit}
+				r.Body = body
+			}
+			rw := &responseWriter{ResponseWriter: w}
+			defer func() {
+				if nice.RecoveryDisabled {
+					return
+				}
+				if artefact := recover(); artefact != nil {
+					metadata := cfg.metadata(r, body)
+					if rw.started() {
+						rw.metadata(metadata)
+					}
+					event := nice.Dispatch(nice.WithMetadata(r.Context(), metadata), artefact)
+					if q != nil {
+						q.panicked(client)
+					}
+					status, expected := cfg.statuses.Match(artefact)
+					if !expected {
+						status = http.StatusInternalServerError
+					}
+					if !expected || event.Handled {
+						nice.Fallthrough(event)
+					}
+					if rw.started() {
+						// Responding over the partial response would only corrupt it.
+						if cfg.panicTrailer != "" && !rw.hijacked {
+							w.Header().Set(http.TrailerPrefix+cfg.panicTrailer, id)
+						}
+						if cfg.abortPartial {
+							panic(http.ErrAbortHandler)
+						}
+						return
+					}
+					http.Error(w, fmt.Sprintf("%s (correlation ID %s)", http.StatusText(status), id), status)
+				}
+			}()
+			next.ServeHTTP(rw.wrapped(), r)
+		})
+	}
+}
+
+// metadata of the request, as configured.
+func (c config) metadata(r *http.Request, body *capturedBody) map[string]string {
+	metadata := map[string]string{
+		"http_method":    r.Method,
+		"http_path":      r.URL.Path,
+		"correlation_id": CorrelationID(r.Context()),
+	}
+	for _, name := range c.headers {
+		values := r.Header.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		value := strings.Join(values, ", ")
+		if slices.ContainsFunc(c.redacted, func(redacted string) bool { return strings.EqualFold(redacted, name) }) {
+			value = Redacted
+		}
+		metadata["http_header_"+strings.ReplaceAll(strings.ToLower(name), "-", "_")] = value
+	}
+	if body != nil {
+		captured := body.buf.Bytes()
+		if c.redactBody != nil {
+			captured = c.redactBody(bytes.Clone(captured))
+		}
+		metadata["http_body"] = string(captured)
+		if body.truncated {
+			metadata["http_body_truncated"] = "true"
+		}
+	}
+	return metadata
+}
+
+type correlationKey struct{}
+
+// CorrelationID returns the correlation ID given to the request by the middleware,
+// or "" if the context is not of such a request.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationKey{}).(string)
+	return id
+}
+
+func newCorrelationID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// capturedBody keeps up to limit bytes read from the request body.
+type capturedBody struct {
+	io.ReadCloser
+	limit     int
+	buf       bytes.Buffer
+	truncated bool
+}
+
+func (b *capturedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if room := b.limit - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(n, room)])
+		b.truncated = b.truncated || n > room
+	} else if n > 0 {
+		b.truncated = true
+	}
+	return n, err
+}
@@ -0,0 +1,176 @@
+package nicehttp_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/nicehttp"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTenant = errors.New("tenant error")
+
+func TestMiddleware(t *testing.T) {
+	t.Run("handled by request context", func(t *testing.T) {
+		var handled any
+		handler := nicehttp.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic(errTenant)
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(nice.WithHandlers(req.Context(),
+			nice.On(errTenant, func(artefact any) { handled = artefact }),
+		))
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, errTenant, handled)
+	})
+
+	t.Run("unhandled", func(t *testing.T) {
+		handler := nicehttp.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic(7)
+		}))
+
+		assert.Panics(t, func() {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+
+	t.Run("not panicked", func(t *testing.T) {
+		handler := nicehttp.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+}
+
+func TestMiddlewareLayeredHandlers(t *testing.T) {
+	var executed []string
+	ctx := nice.WithHandlers(t.Context(), nice.On(reflect.TypeFor[string](), func(any) { executed = append(executed, "global policy") }))
+	ctx = nice.WithHandlers(ctx, nice.On(reflect.TypeFor[string](), func(any) { executed = append(executed, "tenant policy") }))
+	handler := nicehttp.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("tenant panic")
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+
+	assert.Equal(t, []string{"tenant policy"}, executed)
+	assert.Len(t, nice.HandlersFromContext(ctx), 2)
+}
+
+// servePanicking serves the request with the middleware over a handler reading the body, then panicking,
+// and returns the event of the panic.
+func servePanicking(t *testing.T, middleware func(http.Handler) http.Handler, req *http.Request) nice.PanicEvent {
+	t.Helper()
+	nice.KeepRecent(1)
+	t.Cleanup(func() { nice.KeepRecent(0) })
+	handler := middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		panic(errTenant)
+	}))
+	req = req.WithContext(nice.WithHandlers(req.Context(), nice.On(errTenant, func(any) {})))
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	recent := nice.Recent(1)
+	if !assert.Len(t, recent, 1) {
+		t.FailNow()
+	}
+	return recent[0]
+}
+
+func TestMiddlewareCapture(t *testing.T) {
+	t.Run("method and path", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/orders?id=1", nil)
+		req.Header.Set(nicehttp.DefaultCorrelationHeader, "req-1")
+
+		event := servePanicking(t, nicehttp.Middleware, req)
+
+		assert.Equal(t, map[string]string{"http_method": "POST", "http_path": "/orders", "correlation_id": "req-1"}, event.Metadata)
+	})
+
+	t.Run("headers and body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"card":"4111111111111111","qty":1}`))
+		req.Header.Set("User-Agent", "test")
+		req.Header.Set("Authorization", "Bearer secret")
+		middleware := nicehttp.NewMiddleware(
+			nicehttp.CaptureHeaders("User-Agent", "Authorization", "X-Missing"),
+			nicehttp.CaptureBody(1024),
+			nicehttp.RedactBody(func(body []byte) []byte {
+				return regexp.MustCompile(`\d{16}`).ReplaceAll(body, []byte("****"))
+			}),
+		)
+
+		event := servePanicking(t, middleware, req)
+
+		assert.Equal(t, "test", event.Metadata["http_header_user_agent"])
+		assert.Equal(t, nicehttp.Redacted, event.Metadata["http_header_authorization"])
+		assert.NotContains(t, event.Metadata, "http_header_x_missing")
+		assert.Equal(t, `{"card":"****","qty":1}`, event.Metadata["http_body"])
+		assert.NotContains(t, event.Metadata, "http_body_truncated")
+	})
+
+	t.Run("trace context", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		event := servePanicking(t, nicehttp.Middleware, req)
+
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", event.Metadata["trace_id"])
+		assert.Equal(t, "00f067aa0ba902b7", event.Metadata["span_id"])
+	})
+
+	t.Run("truncated body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader("0123456789"))
+
+		event := servePanicking(t, nicehttp.NewMiddleware(nicehttp.CaptureBody(4)), req)
+
+		assert.Equal(t, "0123", event.Metadata["http_body"])
+		assert.Equal(t, "true", event.Metadata["http_body_truncated"])
+	})
+}
+
+func TestMiddlewareCorrelationID(t *testing.T) {
+	var id string
+	panicking := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		id = nicehttp.CorrelationID(r.Context())
+		panic(errTenant)
+	})
+	serve := func(handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req.WithContext(nice.WithHandlers(req.Context(), nice.On(errTenant, func(any) {}))))
+		return rec
+	}
+
+	t.Run("generated", func(t *testing.T) {
+		rec := serve(nicehttp.Middleware(panicking), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Len(t, id, 32)
+		assert.Equal(t, id, rec.Header().Get(nicehttp.DefaultCorrelationHeader))
+		assert.Contains(t, rec.Body.String(), "correlation ID "+id)
+	})
+
+	t.Run("propagated", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Correlation-Id", "abc")
+
+		rec := serve(nicehttp.NewMiddleware(nicehttp.CorrelationHeader("X-Correlation-Id"))(panicking), req)
+
+		assert.Equal(t, "abc", id)
+		assert.Equal(t, "abc", rec.Header().Get("X-Correlation-Id"))
+		assert.Contains(t, rec.Body.String(), "correlation ID abc")
+	})
+}
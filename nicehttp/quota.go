@@ -0,0 +1,117 @@
+package nicehttp
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/antonyho/nice"
+)
+
+// maxQuotaClients bounds the clients counted by PanicQuota. Once exceeded,
+// the clients whose panics all aged out are forgotten, or else the one which panicked least recently.
+const maxQuotaClients = 4096
+
+// PanicQuota rejects the requests of a client whose requests panicked more than panics times within the window,
+// protecting the service from crash-triggering payloads: its requests are responded with 429 Too Many Requests,
+// or the status of QuotaStatus, until its panics age out of the window.
+// The clients are told by their IP address, unless by ClientKey.
+//
+//	nicehttp.NewMiddleware(nicehttp.PanicQuota(5, time.Minute), nicehttp.ClientKey(apiKey))
+func PanicQuota(panics int, window time.Duration) Option {
+	return func(c *config) { c.quotaPanics, c.quotaWindow = panics, window }
+}
+
+// ClientKey tells the client of the request for PanicQuota, e.g. by its API key, instead of its IP address.
+func ClientKey(key func(r *http.Request) string) Option {
+	return func(c *config) { c.clientKey = key }
+}
+
+// QuotaStatus sets the status of the requests rejected by PanicQuota, e.g. 503 Service Unavailable,
+// instead of 429 Too Many Requests.
+func QuotaStatus(code int) Option {
+	return func(c *config) { c.quotaStatus = code }
+}
+
+// quota counts the panics of the clients for PanicQuota.
+type quota struct {
+	mu      sync.Mutex
+	panics  int
+	window  time.Duration
+	clients map[string]*quotaClient
+	// order counts the panics of all clients, ordering their last ones.
+	order uint64
+}
+
+type quotaClient struct {
+	budget *nice.Budget
+	last   uint64
+}
+
+// retryAfter returns how long the client stays over its quota, zero if it is not.
+func (q *quota) retryAfter(client string) time.Duration {
+	q.mu.Lock()
+	c := q.clients[client]
+	q.mu.Unlock()
+	if c == nil {
+		return 0
+	}
+	return c.budget.ExhaustedFor()
+}
+
+// panicked counts the panic against the quota of the client.
+func (q *quota) panicked(client string) {
+	q.mu.Lock()
+	c := q.clients[client]
+	if c == nil {
+		if q.clients == nil {
+			q.clients = make(map[string]*quotaClient)
+		}
+		if len(q.clients) >= maxQuotaClients {
+			q.evict()
+		}
+		c = &quotaClient{budget: nice.NewBudget(q.panics, q.window)}
+		q.clients[client] = c
+	}
+	q.order++
+	c.last = q.order
+	q.mu.Unlock()
+	c.budget.Report(nice.PanicEvent{})
+}
+
+// evict forgets the clients whose panics all aged out, or else the one which panicked least recently.
+// It shall be called with the mutex locked.
+func (q *quota) evict() {
+	var oldest string
+	var oldestLast uint64 = math.MaxUint64
+	for client, c := range q.clients {
+		if c.budget.Spent() == 0 {
+			delete(q.clients, client)
+			continue
+		}
+		if c.last < oldestLast {
+			oldest, oldestLast = client, c.last
+		}
+	}
+	if len(q.clients) >= maxQuotaClients {
+		delete(q.clients, oldest)
+	}
+}
+
+// reject responds the request of a client over its quota for the duration.
+func (q *quota) reject(w http.ResponseWriter, status int, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	http.Error(w, http.StatusText(status), status)
+}
+
+// remoteIP is the default ClientKey.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,107 @@
+package nicehttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/nicehttp"
+	"github.com/antonyho/nice/nicetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPanicQuota(t *testing.T) {
+	clock := nicetest.UseFakeClock(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	handler := nicehttp.NewMiddleware(nicehttp.PanicQuota(2, time.Minute))(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/crash" {
+			panic(errTenant)
+		}
+	}))
+	serve := func(path, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req.WithContext(nice.WithHandlers(req.Context(), nice.On(errTenant, func(any) {}))))
+		return rec
+	}
+
+	for range 2 {
+		assert.Equal(t, http.StatusInternalServerError, serve("/crash", "192.0.2.1:1234").Code)
+	}
+	clock.Advance(20 * time.Second)
+	assert.Equal(t, http.StatusInternalServerError, serve("/crash", "192.0.2.1:1234").Code)
+	clock.Advance(10 * time.Second)
+	rec := serve("/", "192.0.2.1:5678")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, "the client is over its quota")
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"), "until its first panics age out of its window")
+	assert.Equal(t, http.StatusOK, serve("/", "192.0.2.2:1234").Code, "other clients are served")
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, http.StatusOK, serve("/", "192.0.2.1:1234").Code, "the panics aged out")
+}
+
+func TestPanicQuotaEviction(t *testing.T) {
+	// The quota counts up to 4096 clients.
+	const clients = 4096
+	clock := nicetest.UseFakeClock(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	handler := nicehttp.NewMiddleware(
+		nicehttp.PanicQuota(1, time.Minute),
+		nicehttp.ClientKey(func(r *http.Request) string { return r.Header.Get("X-Api-Key") }),
+	)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/crash" {
+			panic(errTenant)
+		}
+	}))
+	serve := func(path, key string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Api-Key", key)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req.WithContext(nice.WithHandlers(req.Context(), nice.On(errTenant, func(any) {}))))
+		return rec.Code
+	}
+
+	for i := range clients - 1 {
+		serve("/crash", "client-"+strconv.Itoa(i))
+	}
+	clock.Advance(30 * time.Second)
+	serve("/crash", "abuser")
+	serve("/crash", "abuser")
+	assert.Equal(t, http.StatusTooManyRequests, serve("/", "abuser"))
+
+	serve("/crash", "newcomer")
+	assert.Equal(t, http.StatusTooManyRequests, serve("/", "abuser"), "the client which panicked least recently is forgotten")
+	serve("/crash", "client-1")
+	assert.Equal(t, http.StatusTooManyRequests, serve("/", "client-1"), "the other clients are still counted")
+
+	clock.Advance(31 * time.Second)
+	serve("/crash", "latecomer")
+	assert.Equal(t, http.StatusTooManyRequests, serve("/", "abuser"), "the clients whose panics aged out are forgotten first")
+	serve("/crash", "client-2")
+	assert.Equal(t, http.StatusOK, serve("/", "client-2"), "a forgotten client starts over")
+}
+
+func TestPanicQuotaOptions(t *testing.T) {
+	handler := nicehttp.NewMiddleware(
+		nicehttp.PanicQuota(0, time.Minute),
+		nicehttp.PanicQuota(1, time.Minute),
+		nicehttp.ClientKey(func(r *http.Request) string { return r.Header.Get("X-Api-Key") }),
+		nicehttp.QuotaStatus(http.StatusServiceUnavailable),
+	)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { panic(errTenant) }))
+	serve := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Api-Key", key)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req.WithContext(nice.WithHandlers(req.Context(), nice.On(errTenant, func(any) {}))))
+		return rec
+	}
+
+	serve("abuser")
+	serve("abuser")
+	rec := serve("abuser")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusInternalServerError, serve("other").Code)
+}
@@ -0,0 +1,49 @@
+package nicehttp
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/antonyho/nice"
+)
+
+// Readiness is an in-process readiness flag, which turns not ready while the panic budget is exhausted,
+// letting the platform stop routing traffic to an instance stuck in recover-crash cycles.
+// It is ready again once the panics slide out of the window of the budget.
+// It serves as the HTTP readiness probe, e.g. of Kubernetes.
+//
+//	budget := nice.NewBudget(10, time.Minute)
+//	nice.AddReporter(budget)
+//	readiness := nicehttp.NewReadiness(budget)
+//	mux.Handle("/readyz", readiness)
+type Readiness struct {
+	budget   *nice.Budget
+	notReady atomic.Bool
+}
+
+// NewReadiness returns a Readiness of the budget, which is ready.
+func NewReadiness(budget *nice.Budget) *Readiness {
+	return &Readiness{budget: budget}
+}
+
+// SetReady sets the readiness of the application on its own, e.g. once it has warmed up.
+func (r *Readiness) SetReady(ready bool) {
+	r.notReady.Store(!ready)
+}
+
+// Ready tells whether the application is ready and the panic budget is not exhausted.
+func (r *Readiness) Ready() bool {
+	return !r.notReady.Load() && !r.budget.Exhausted()
+}
+
+// ServeHTTP responds with 200 OK if ready, or 503 Service Unavailable otherwise.
+func (r *Readiness) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	switch {
+	case r.notReady.Load():
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+	case r.budget.Exhausted():
+		http.Error(w, "panic budget exhausted", http.StatusServiceUnavailable)
+	default:
+		_, _ = w.Write([]byte("ok\n"))
+	}
+}
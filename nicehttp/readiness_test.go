@@ -0,0 +1,39 @@
+package nicehttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/nicehttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadiness(t *testing.T) {
+	budget := nice.NewBudget(1, time.Minute)
+	readiness := nicehttp.NewReadiness(budget)
+	probe := func() int {
+		rec := httptest.NewRecorder()
+		readiness.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return rec.Code
+	}
+
+	assert.True(t, readiness.Ready())
+	assert.Equal(t, http.StatusOK, probe())
+
+	budget.Report(nice.PanicEvent{})
+	budget.Report(nice.PanicEvent{})
+	assert.False(t, readiness.Ready())
+	assert.Equal(t, http.StatusServiceUnavailable, probe())
+}
+
+func TestReadinessSetReady(t *testing.T) {
+	readiness := nicehttp.NewReadiness(nice.NewBudget(1, time.Minute))
+
+	readiness.SetReady(false)
+	assert.False(t, readiness.Ready())
+	readiness.SetReady(true)
+	assert.True(t, readiness.Ready())
+}
@@ -0,0 +1,58 @@
+package nicehttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/antonyho/nice"
+)
+
+// AttemptFunc is an attempt of the retry loop of an HTTP client, given the attempt number from 1,
+// as the per-attempt funcs of retryable HTTP clients.
+type AttemptFunc func(ctx context.Context, attempt int) (*http.Response, error)
+
+// RecoverAttempt wraps the attempt so its panic, e.g. of a request signer or a response decoder,
+// fails the attempt with a *nice.PanicError, which the retry loop retries as a transport error,
+// rather than crashing the client. The panic is dispatched as by nice.ProtectAttempt,
+// with the attempt number recorded as "attempt" in the event metadata.
+func RecoverAttempt(fn AttemptFunc) AttemptFunc {
+	return func(ctx context.Context, attempt int) (*http.Response, error) {
+		return nice.ProtectAttempt(ctx, attempt, func(ctx context.Context) (*http.Response, error) {
+			return fn(ctx, attempt)
+		})
+	}
+}
+
+// Retry calls the attempt, recovered by RecoverAttempt, up to attempts times, waiting the backoff of the policy
+// between them, until it returns a response under 500 or the context is done.
+// Errors, panics included, 5xx responses and nil responses are retried; the bodies of the retried responses are closed.
+// It returns the response or the error of the last attempt.
+//
+//	resp, err := nicehttp.Retry(ctx, 3, nice.DefaultBackoff, func(ctx context.Context, attempt int) (*http.Response, error) {
+//		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return client.Do(sign(req))
+//	})
+func Retry(ctx context.Context, attempts int, policy nice.BackoffPolicy, fn AttemptFunc) (*http.Response, error) {
+	recovered := RecoverAttempt(fn)
+	for attempt := 1; ; attempt++ {
+		resp, err := recovered(ctx, attempt)
+		if err == nil && resp == nil {
+			err = fmt.Errorf("nicehttp: attempt %d: no response", attempt)
+		}
+		if (err == nil && resp.StatusCode < http.StatusInternalServerError) || attempt >= attempts || ctx.Err() != nil {
+			return resp, err
+		}
+		if err == nil {
+			resp.Body.Close()
+			err = fmt.Errorf("nicehttp: attempt %d: %s", attempt, resp.Status)
+		}
+		if sleepErr := nice.Sleep(ctx, policy, attempt); sleepErr != nil {
+			return nil, errors.Join(err, sleepErr)
+		}
+	}
+}
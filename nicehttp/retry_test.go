@@ -0,0 +1,87 @@
+package nicehttp_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/nicehttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoverAttempt(t *testing.T) {
+	nice.KeepRecent(1)
+	t.Cleanup(func() { nice.KeepRecent(0) })
+	errSigner := errors.New("signer broken")
+	attempt := nicehttp.RecoverAttempt(func(context.Context, int) (*http.Response, error) {
+		panic(errSigner)
+	})
+
+	resp, err := attempt(context.Background(), 2)
+
+	assert.Nil(t, resp)
+	var panicErr *nice.PanicError
+	if assert.ErrorAs(t, err, &panicErr) {
+		assert.Equal(t, errSigner, panicErr.Value)
+		assert.NotEmpty(t, panicErr.Stack)
+	}
+	assert.ErrorIs(t, err, errSigner)
+	if recent := nice.Recent(1); assert.Len(t, recent, 1) {
+		assert.Equal(t, "2", recent[0].Metadata["attempt"])
+	}
+}
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	var attempts []int
+	resp, err := nicehttp.Retry(context.Background(), 3, nice.ConstantBackoff(0), func(ctx context.Context, attempt int) (*http.Response, error) {
+		attempts = append(attempts, attempt)
+		if attempt == 1 {
+			panic("decoder broken")
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		if err != nil {
+			return nil, err
+		}
+		return server.Client().Do(req)
+	})
+
+	if assert.NoError(t, err) {
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, []int{1, 2, 3}, attempts, "the panic and the 5xx are retried")
+
+	t.Run("gives up", func(t *testing.T) {
+		attempts := 0
+		_, err := nicehttp.Retry(context.Background(), 2, nice.ConstantBackoff(0), func(context.Context, int) (*http.Response, error) {
+			attempts++
+			panic("decoder broken")
+		})
+		var panicErr *nice.PanicError
+		assert.ErrorAs(t, err, &panicErr)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		attempts := 0
+		resp, err := nicehttp.Retry(context.Background(), 2, nice.ConstantBackoff(0), func(context.Context, int) (*http.Response, error) {
+			attempts++
+			return nil, nil
+		})
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "nicehttp: attempt 2: no response")
+		assert.Equal(t, 2, attempts, "a nil response is retried")
+	})
+}
@@ -0,0 +1,15 @@
+package nicehttp
+
+import (
+	"expvar"
+
+	"github.com/antonyho/nice"
+)
+
+// PublishStats publishes nice.Stats as the expvar of the name, served by /debug/vars.
+// It panics if the name is already published, as expvar.Publish.
+//
+//	nicehttp.PublishStats("nice_handlers")
+func PublishStats(name string) {
+	expvar.Publish(name, expvar.Func(func() any { return nice.Stats() }))
+}
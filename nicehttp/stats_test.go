@@ -0,0 +1,19 @@
+package nicehttp_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/nicehttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishStats(t *testing.T) {
+	nicehttp.PublishStats("nice_test_handlers")
+
+	var stats []nice.HandlerStats
+	assert.NoError(t, json.Unmarshal([]byte(expvar.Get("nice_test_handlers").String()), &stats))
+	assert.Equal(t, nice.Stats(), stats)
+}
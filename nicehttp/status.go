@@ -0,0 +1,48 @@
+package nicehttp
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/antonyho/nice/dispatch"
+)
+
+// StatusMap maps targets, accepted as by nice.Tackle, to the status codes responded by the middleware
+// for the panics they match, e.g. of validation errors raised by lower layers.
+// The targets are map keys, so they shall be comparable: matchers holding funcs, as of nice.MessageMatches,
+// cannot be targets of a StatusMap.
+//
+//	nicehttp.NewMiddleware(nicehttp.Statuses(nicehttp.StatusMap{
+//		ErrInvalidOrder:  http.StatusBadRequest,
+//		store.ErrConflict: http.StatusConflict,
+//	}))
+type StatusMap map[any]int
+
+// Statuses has the middleware respond the panics matching the targets of the map with their status codes,
+// instead of 500 Internal Server Error. The panics are still dispatched,
+// but a panic matching a target is expected, and does not fall through even if no handler matched.
+// Of several matching targets, the one of the lowest status code is taken.
+func Statuses(statuses StatusMap) Option {
+	return func(c *config) { c.statuses = statuses.engine() }
+}
+
+type statusEntry struct {
+	target any
+	status int
+}
+
+// engine of the map, matching its targets in order: by status code, then by target description.
+func (m StatusMap) engine() dispatch.Engine[int] {
+	entries := make([]statusEntry, 0, len(m))
+	for target, status := range m {
+		entries = append(entries, statusEntry{target, status})
+	}
+	slices.SortFunc(entries, func(a, b statusEntry) int {
+		return cmp.Or(cmp.Compare(a.status, b.status), cmp.Compare(dispatch.Describe(a.target), dispatch.Describe(b.target)))
+	})
+	var engine dispatch.Engine[int]
+	for _, entry := range entries {
+		engine.Add(entry.status, entry.target)
+	}
+	return engine
+}
@@ -0,0 +1,49 @@
+package nicehttp_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/nicehttp"
+	"github.com/stretchr/testify/assert"
+)
+
+var errInvalid = errors.New("invalid order")
+
+type conflictError struct{}
+
+func (conflictError) Error() string { return "conflict" }
+
+// conflictMatcher is a comparable Matcher, as the keys of a StatusMap shall be.
+type conflictMatcher struct{}
+
+func (conflictMatcher) Match(artefact any) bool {
+	_, matched := artefact.(conflictError)
+	return matched
+}
+
+func TestStatuses(t *testing.T) {
+	middleware := nicehttp.NewMiddleware(nicehttp.Statuses(nicehttp.StatusMap{
+		errInvalid:        http.StatusBadRequest,
+		conflictError{}:   http.StatusConflict,
+		conflictMatcher{}: http.StatusUnprocessableEntity,
+	}))
+	serve := func(artefact any) *httptest.ResponseRecorder {
+		handler := middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { panic(artefact) }))
+		req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+		req = req.WithContext(nice.WithHandlers(req.Context(), nice.On(errTenant, func(any) {})))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(errInvalid)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "expected panics are responded without a handler")
+	assert.Contains(t, rec.Body.String(), "Bad Request (correlation ID ")
+	assert.Equal(t, http.StatusConflict, serve(conflictError{}).Code, "the lowest status code of the matching targets")
+	assert.Equal(t, http.StatusInternalServerError, serve(errTenant).Code)
+	assert.PanicsWithValue(t, "unexpected", func() { serve("unexpected") })
+}
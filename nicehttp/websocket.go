@@ -0,0 +1,100 @@
+package nicehttp
+
+import (
+	"context"
+	"net"
+
+	"github.com/antonyho/nice"
+)
+
+// The close codes of RFC 6455 for ClosePolicy.
+const (
+	CloseGoingAway       = 1001
+	ClosePolicyViolation = 1008
+	CloseInternalError   = 1011
+)
+
+// WebSocketConn is the connection of a WebSocket library, e.g. of gorilla/websocket or nhooyr.io/websocket,
+// adapted by a few lines:
+//
+//	type conn struct{ *websocket.Conn }
+//
+//	func (c conn) Close(code int, reason string) error {
+//		msg := websocket.FormatCloseMessage(code, reason)
+//		c.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+//		return c.Conn.Close()
+//	}
+type WebSocketConn interface {
+	// RemoteAddr returns the address of the peer.
+	RemoteAddr() net.Addr
+	// Close sends the close frame of the code and reason, and closes the connection.
+	Close(code int, reason string) error
+}
+
+// WebSocketOption configures the guard of GuardWebSocket.
+type WebSocketOption func(*WebSocketGuard)
+
+// ClosePolicy sets the close code and reason of the connection given the event of the panic,
+// instead of CloseInternalError. A code of 0 keeps the connection open,
+// e.g. for the panics of a message handler which spare the connection.
+func ClosePolicy(policy func(event nice.PanicEvent) (code int, reason string)) WebSocketOption {
+	return func(g *WebSocketGuard) { g.policy = policy }
+}
+
+// WebSocketGuard recovers the panics of the handlers of a WebSocket connection, as returned by GuardWebSocket.
+type WebSocketGuard struct {
+	conn   WebSocketConn
+	policy func(event nice.PanicEvent) (code int, reason string)
+}
+
+// GuardWebSocket returns the guard of the connection, for its handler and for the handlers of its messages.
+//
+//	guard := nicehttp.GuardWebSocket(conn{c})
+//	guard.Run(r.Context(), func(ctx context.Context) {
+//		for {
+//			_, msg, err := c.ReadMessage()
+//			if err != nil {
+//				return
+//			}
+//			if !guard.Run(ctx, func(ctx context.Context) { handle(ctx, msg) }) {
+//				return
+//			}
+//		}
+//	})
+func GuardWebSocket(conn WebSocketConn, opts ...WebSocketOption) *WebSocketGuard {
+	g := &WebSocketGuard{conn: conn, policy: func(nice.PanicEvent) (int, string) {
+		return CloseInternalError, "internal error"
+	}}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Run fn, recovering its panic, which is dispatched to the handlers carried by the context,
+// then to the globally registered handlers, with the remote address of the connection
+// recorded as ws_remote_addr in the event metadata.
+// The connection is then closed as by the ClosePolicy, and Run returns whether it is still open.
+// An unhandled panic falls through once the connection is closed.
+func (g *WebSocketGuard) Run(ctx context.Context, fn func(ctx context.Context)) (open bool) {
+	defer func() {
+		if nice.RecoveryDisabled {
+			return
+		}
+		if artefact := recover(); artefact != nil {
+			metadata := map[string]string{}
+			if addr := g.conn.RemoteAddr(); addr != nil {
+				metadata["ws_remote_addr"] = addr.String()
+			}
+			event := nice.Dispatch(nice.WithMetadata(ctx, metadata), artefact)
+			code, reason := g.policy(event)
+			if open = code == 0; !open {
+				// The connection may be broken already, the panic is what is reported.
+				_ = g.conn.Close(code, reason)
+			}
+			nice.Fallthrough(event)
+		}
+	}()
+	fn(ctx)
+	return true
+}
@@ -0,0 +1,69 @@
+package nicehttp_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/nicehttp"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockConn records how it is closed.
+type mockConn struct {
+	closed bool
+	code   int
+	reason string
+}
+
+func (c *mockConn) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 4242}
+}
+
+func (c *mockConn) Close(code int, reason string) error {
+	c.closed, c.code, c.reason = true, code, reason
+	return nil
+}
+
+func TestWebSocketGuard(t *testing.T) {
+	errMessage := errors.New("bad message")
+	ctx := nice.WithHandlers(context.Background(), nice.On(errMessage, func(any) {}))
+
+	t.Run("closed", func(t *testing.T) {
+		nice.KeepRecent(1)
+		t.Cleanup(func() { nice.KeepRecent(0) })
+		conn := &mockConn{}
+
+		open := nicehttp.GuardWebSocket(conn).Run(ctx, func(context.Context) { panic(errMessage) })
+
+		assert.False(t, open)
+		assert.Equal(t, &mockConn{closed: true, code: nicehttp.CloseInternalError, reason: "internal error"}, conn)
+		if recent := nice.Recent(1); assert.Len(t, recent, 1) {
+			assert.Equal(t, "192.0.2.1:4242", recent[0].Metadata["ws_remote_addr"])
+		}
+	})
+
+	t.Run("close policy", func(t *testing.T) {
+		conn := &mockConn{}
+		guard := nicehttp.GuardWebSocket(conn, nicehttp.ClosePolicy(func(event nice.PanicEvent) (int, string) {
+			if errors.Is(event.Artefact.(error), errMessage) {
+				return 0, ""
+			}
+			return nicehttp.ClosePolicyViolation, "unexpected"
+		}))
+
+		assert.True(t, guard.Run(ctx, func(context.Context) { panic(errMessage) }), "the connection is kept open")
+		assert.False(t, conn.closed)
+		assert.True(t, guard.Run(ctx, func(context.Context) {}))
+	})
+
+	t.Run("unhandled", func(t *testing.T) {
+		conn := &mockConn{}
+		assert.PanicsWithValue(t, "unexpected", func() {
+			nicehttp.GuardWebSocket(conn).Run(ctx, func(context.Context) { panic("unexpected") })
+		})
+		assert.True(t, conn.closed, "the connection is closed before falling through")
+	})
+}
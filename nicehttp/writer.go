@@ -0,0 +1,227 @@
+package nicehttp
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+// PanicTrailer has the middleware send the trailer of the name, e.g. X-Panic, with the correlation ID as its value,
+// for a panic after the response started, so clients of streamed responses can tell they are incomplete.
+// Trailers are sent by chunked HTTP/1.1 responses and by HTTP/2.
+func PanicTrailer(name string) Option {
+	return func(c *config) { c.panicTrailer = name }
+}
+
+// AbortPartial has the middleware abort the connection for a panic after the response started,
+// by http.ErrAbortHandler, so clients do not take the partial response for a complete one.
+func AbortPartial() Option {
+	return func(c *config) { c.abortPartial = true }
+}
+
+// responseWriter tracks the state of the response, so a panic after it started is not responded over.
+type responseWriter struct {
+	http.ResponseWriter
+	status   int
+	written  int64
+	hijacked bool
+}
+
+// wrapped returns the writer passing through the http.Flusher, http.Hijacker, http.Pusher and io.ReaderFrom
+// of the ResponseWriter wrapped, so protected handlers keep serving SSE, WebSockets and files by sendfile.
+// It implements only those the ResponseWriter wrapped does, for handlers checking for them.
+func (w *responseWriter) wrapped() http.ResponseWriter {
+	_, f := w.ResponseWriter.(http.Flusher)
+	_, h := w.ResponseWriter.(http.Hijacker)
+	_, p := w.ResponseWriter.(http.Pusher)
+	_, r := w.ResponseWriter.(io.ReaderFrom)
+	switch {
+	case f && h && p && r:
+		return struct {
+			*responseWriter
+			flusher
+			hijacker
+			pusher
+			readerFrom
+		}{w, flusher{w}, hijacker{w}, pusher{w}, readerFrom{w}}
+	case !f && h && p && r:
+		return struct {
+			*responseWriter
+			hijacker
+			pusher
+			readerFrom
+		}{w, hijacker{w}, pusher{w}, readerFrom{w}}
+	case f && !h && p && r:
+		return struct {
+			*responseWriter
+			flusher
+			pusher
+			readerFrom
+		}{w, flusher{w}, pusher{w}, readerFrom{w}}
+	case !f && !h && p && r:
+		return struct {
+			*responseWriter
+			pusher
+			readerFrom
+		}{w, pusher{w}, readerFrom{w}}
+	case f && h && !p && r:
+		return struct {
+			*responseWriter
+			flusher
+			hijacker
+			readerFrom
+		}{w, flusher{w}, hijacker{w}, readerFrom{w}}
+	case !f && h && !p && r:
+		return struct {
+			*responseWriter
+			hijacker
+			readerFrom
+		}{w, hijacker{w}, readerFrom{w}}
+	case f && !h && !p && r:
+		return struct {
+			*responseWriter
+			flusher
+			readerFrom
+		}{w, flusher{w}, readerFrom{w}}
+	case !f && !h && !p && r:
+		return struct {
+			*responseWriter
+			readerFrom
+		}{w, readerFrom{w}}
+	case f && h && p && !r:
+		return struct {
+			*responseWriter
+			flusher
+			hijacker
+			pusher
+		}{w, flusher{w}, hijacker{w}, pusher{w}}
+	case !f && h && p && !r:
+		return struct {
+			*responseWriter
+			hijacker
+			pusher
+		}{w, hijacker{w}, pusher{w}}
+	case f && !h && p && !r:
+		return struct {
+			*responseWriter
+			flusher
+			pusher
+		}{w, flusher{w}, pusher{w}}
+	case !f && !h && p && !r:
+		return struct {
+			*responseWriter
+			pusher
+		}{w, pusher{w}}
+	case f && h && !p && !r:
+		return struct {
+			*responseWriter
+			flusher
+			hijacker
+		}{w, flusher{w}, hijacker{w}}
+	case !f && h && !p && !r:
+		return struct {
+			*responseWriter
+			hijacker
+		}{w, hijacker{w}}
+	case f && !h && !p && !r:
+		return struct {
+			*responseWriter
+			flusher
+		}{w, flusher{w}}
+	default:
+		return w
+	}
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 && status >= http.StatusOK {
+		// Informational responses, e.g. 103 Early Hints, do not start the response.
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+type flusher struct {
+	w *responseWriter
+}
+
+func (f flusher) Flush() {
+	_ = f.FlushError()
+}
+
+// FlushError flushes as Flush, returning the error of the ResponseWriter wrapped, for http.ResponseController.
+func (f flusher) FlushError() error {
+	if f.w.status == 0 {
+		f.w.status = http.StatusOK
+	}
+	if fe, ok := f.w.ResponseWriter.(interface{ FlushError() error }); ok {
+		return fe.FlushError()
+	}
+	f.w.ResponseWriter.(http.Flusher).Flush()
+	return nil
+}
+
+type hijacker struct {
+	w *responseWriter
+}
+
+func (h hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := h.w.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil {
+		h.w.hijacked = true
+	}
+	return conn, rw, err
+}
+
+type pusher struct {
+	w *responseWriter
+}
+
+func (p pusher) Push(target string, opts *http.PushOptions) error {
+	return p.w.ResponseWriter.(http.Pusher).Push(target, opts)
+}
+
+type readerFrom struct {
+	w *responseWriter
+}
+
+func (r readerFrom) ReadFrom(src io.Reader) (int64, error) {
+	if r.w.status == 0 {
+		r.w.status = http.StatusOK
+	}
+	n, err := r.w.ResponseWriter.(io.ReaderFrom).ReadFrom(src)
+	r.w.written += n
+	return n, err
+}
+
+// Unwrap returns the ResponseWriter wrapped, for http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// started tells whether the response started, i.e. its header was written, or its connection hijacked.
+func (w *responseWriter) started() bool {
+	return w.status != 0 || w.hijacked
+}
+
+// metadata of the partial response.
+func (w *responseWriter) metadata(metadata map[string]string) {
+	metadata["http_partial_response"] = "true"
+	if w.hijacked {
+		metadata["http_hijacked"] = "true"
+		return
+	}
+	metadata["http_status"] = strconv.Itoa(w.status)
+	metadata["http_written_bytes"] = strconv.FormatInt(w.written, 10)
+}
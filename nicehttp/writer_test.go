@@ -0,0 +1,152 @@
+package nicehttp_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/nicehttp"
+	"github.com/stretchr/testify/assert"
+)
+
+// streaming writes a chunk of the response, flushed, before panicking.
+var streaming = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	_, _ = io.WriteString(w, "data: 1\n\n")
+	w.(http.Flusher).Flush()
+	panic(errTenant)
+})
+
+func TestMiddlewarePartialResponse(t *testing.T) {
+	nice.KeepRecent(1)
+	t.Cleanup(func() { nice.KeepRecent(0) })
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req = req.WithContext(nice.WithHandlers(req.Context(), nice.On(errTenant, func(any) {})))
+	rec := httptest.NewRecorder()
+
+	nicehttp.Middleware(streaming).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "data: 1\n\n", rec.Body.String(), "the partial response is not responded over")
+	if recent := nice.Recent(1); assert.Len(t, recent, 1) {
+		assert.Equal(t, "true", recent[0].Metadata["http_partial_response"])
+		assert.Equal(t, "200", recent[0].Metadata["http_status"])
+		assert.Equal(t, "9", recent[0].Metadata["http_written_bytes"])
+	}
+}
+
+func TestMiddlewarePartialResponseEnding(t *testing.T) {
+	serve := func(t *testing.T, opts ...nicehttp.Option) (*http.Response, error) {
+		middleware := nicehttp.NewMiddleware(opts...)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r = r.WithContext(nice.WithHandlers(r.Context(), nice.On(errTenant, func(any) {})))
+			middleware(streaming).ServeHTTP(w, r)
+		}))
+		t.Cleanup(server.Close)
+		return server.Client().Get(server.URL)
+	}
+
+	t.Run("trailer", func(t *testing.T) {
+		resp, err := serve(t, nicehttp.PanicTrailer("X-Panic"), nicehttp.CorrelationHeader("X-Request-Id"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "data: 1\n\n", string(body))
+		assert.Equal(t, resp.Header.Get("X-Request-Id"), resp.Trailer.Get("X-Panic"))
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		resp, err := serve(t, nicehttp.AbortPartial())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		_, err = io.ReadAll(resp.Body)
+		assert.Error(t, err, "the connection is aborted")
+	})
+}
+
+func TestMiddlewarePassthrough(t *testing.T) {
+	t.Run("unsupported", func(t *testing.T) {
+		var hijacker, pusher, readerFrom bool
+		handler := nicehttp.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, hijacker = w.(http.Hijacker)
+			_, pusher = w.(http.Pusher)
+			_, readerFrom = w.(io.ReaderFrom)
+			w.(http.Flusher).Flush()
+		}))
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.False(t, hijacker, "the recorder is no Hijacker")
+		assert.False(t, pusher, "the recorder is no Pusher")
+		assert.False(t, readerFrom, "the recorder is no ReaderFrom")
+		assert.True(t, rec.Flushed)
+	})
+
+	t.Run("read from", func(t *testing.T) {
+		var copied int64
+		middleware := nicehttp.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			copied, _ = w.(io.ReaderFrom).ReadFrom(strings.NewReader("file"))
+		}))
+		server := httptest.NewServer(middleware)
+		defer server.Close()
+
+		resp, err := server.Client().Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "file", string(body))
+		assert.Equal(t, int64(4), copied)
+	})
+
+	t.Run("hijacked", func(t *testing.T) {
+		nice.KeepRecent(1)
+		t.Cleanup(func() { nice.KeepRecent(0) })
+		middleware := nicehttp.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_, _ = io.WriteString(conn, "HTTP/1.1 200 OK\r\nContent-Length: 2\r\nConnection: close\r\n\r\nok")
+			conn.Close()
+			panic(errTenant)
+		}))
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			middleware.ServeHTTP(w, r.WithContext(nice.WithHandlers(r.Context(), nice.On(errTenant, func(any) {}))))
+		}))
+		defer server.Close()
+
+		resp, err := server.Client().Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "ok", string(body))
+		if recent := nice.Recent(1); assert.Len(t, recent, 1) {
+			assert.Equal(t, "true", recent[0].Metadata["http_hijacked"])
+		}
+	})
+
+	t.Run("response controller", func(t *testing.T) {
+		var err error
+		handler := nicehttp.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			err = http.NewResponseController(w).Flush()
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.NoError(t, err)
+		assert.True(t, rec.Flushed)
+	})
+}
@@ -0,0 +1,120 @@
+package nicetest
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antonyho/nice"
+)
+
+// FakeClock is a nice.Clock whose time only moves by Advance,
+// so the rate limits, storm windows, budgets and backoff can be tested deterministically.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock returns a FakeClock at the start time.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// UseFakeClock sets a FakeClock at the start time as the clock of nice, until the test ends.
+//
+//	clock := nicetest.UseFakeClock(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	...
+//	clock.Advance(time.Minute)
+func UseFakeClock(t testing.TB, start time.Time) *FakeClock {
+	t.Helper()
+	c := NewFakeClock(start)
+	nice.SetClock(c)
+	t.Cleanup(func() { nice.SetClock(nil) })
+	return c
+}
+
+// Now returns the time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a Timer firing once the clock is advanced by the duration.
+func (c *FakeClock) NewTimer(d time.Duration) nice.Timer {
+	return c.add(&fakeTimer{c: make(chan time.Time, 1)}, d)
+}
+
+// AfterFunc returns a Timer calling f once the clock is advanced by the duration.
+// Unlike the system clock, f is called by Advance, before it returns.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) nice.Timer {
+	return c.add(&fakeTimer{f: f}, d)
+}
+
+// Advance moves the time of the clock forward by the duration,
+// firing the due timers in the order of their times.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	var due []*fakeTimer
+	c.timers = slices.DeleteFunc(c.timers, func(t *fakeTimer) bool {
+		if t.when.After(c.now) {
+			return false
+		}
+		due = append(due, t)
+		return true
+	})
+	now := c.now
+	c.mu.Unlock()
+
+	slices.SortStableFunc(due, func(a, b *fakeTimer) int { return a.when.Compare(b.when) })
+	for _, t := range due {
+		if t.f != nil {
+			t.f()
+			continue
+		}
+		t.c <- now
+	}
+}
+
+// Timers returns the number of timers yet to fire,
+// e.g. to wait for a goroutine under test to sleep before advancing the clock.
+func (c *FakeClock) Timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// add the timer due after the duration.
+func (c *FakeClock) add(t *fakeTimer, d time.Duration) *fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t.clock = c
+	t.when = c.now.Add(d)
+	c.timers = append(c.timers, t)
+	return t
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	when  time.Time
+	c     chan time.Time
+	f     func()
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	i := slices.Index(t.clock.timers, t)
+	if i < 0 {
+		return false
+	}
+	t.clock.timers = slices.Delete(t.clock.timers, i, i+1)
+	return true
+}
@@ -0,0 +1,71 @@
+package nicetest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/nicetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := nicetest.NewFakeClock(start)
+
+	var fired []string
+	clock.AfterFunc(2*time.Second, func() { fired = append(fired, "second") })
+	clock.AfterFunc(time.Second, func() { fired = append(fired, "first") })
+	stopped := clock.AfterFunc(time.Second, func() { fired = append(fired, "stopped") })
+	timer := clock.NewTimer(3 * time.Second)
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop(), "already stopped")
+	assert.Equal(t, 3, clock.Timers())
+
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, []string{"first", "second"}, fired)
+	assert.Equal(t, start.Add(2*time.Second), clock.Now())
+	assert.Len(t, timer.C(), 0)
+
+	clock.Advance(time.Second)
+	assert.Equal(t, start.Add(3*time.Second), <-timer.C())
+	assert.Equal(t, 0, clock.Timers())
+	assert.False(t, timer.Stop(), "already fired")
+}
+
+func TestUseFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := nicetest.UseFakeClock(t, start)
+
+	t.Run("sleep", func(t *testing.T) {
+		slept := make(chan error)
+		go func() { slept <- nice.Sleep(context.Background(), nice.ConstantBackoff(time.Hour), 1) }()
+		for clock.Timers() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		clock.Advance(time.Hour)
+		select {
+		case err := <-slept:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("Sleep does not wake up once the clock advanced.")
+		}
+	})
+
+	t.Run("sleep beyond deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		err := nice.Sleep(ctx, nice.ConstantBackoff(time.Hour), 1)
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "the deadline is by the system clock, not the fake one")
+	})
+
+	t.Run("budget", func(t *testing.T) {
+		budget := nice.NewBudget(1, time.Minute)
+		budget.Report(nice.PanicEvent{})
+		budget.Report(nice.PanicEvent{})
+		assert.True(t, budget.Exhausted())
+		clock.Advance(time.Minute + time.Second)
+		assert.Equal(t, 0, budget.Spent())
+	})
+}
@@ -0,0 +1,78 @@
+package nicetest
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/antonyho/nice"
+)
+
+// EnvCrashing is the environment variable naming the test run by RunCrashing in the child process.
+const EnvCrashing = "NICETEST_CRASHING"
+
+// Crash is the outcome of the child process run by RunCrashing.
+type Crash struct {
+	// Crashed tells whether the child crashed with Go panic output.
+	Crashed bool
+	// Event parsed from the crash output by nice.ParseCrash, with a *nice.CrashError artefact.
+	Event nice.PanicEvent
+	// ExitCode of the child, which is 2 for a panic falling through.
+	ExitCode int
+	// Stderr of the child.
+	Stderr string
+}
+
+// RunCrashing runs fn in a child process, re-executing the test binary for the calling test only,
+// and returns how the child exited. A panic of fn falls through and crashes the child
+// instead of the test run, so the fallthrough and the exit codes are tested first-class.
+//
+//	crash := nicetest.RunCrashing(t, func() {
+//		defer nice.Tackle(ErrNotFound).With(ignore)
+//		panic(ErrUnavailable)
+//	})
+//	assert.True(t, crash.Crashed)
+//	assert.Equal(t, ErrUnavailable.Error(), crash.Event.Message())
+//
+// The calling test runs again in the child up to RunCrashing, which shall happen before any side effect.
+// In the child, RunCrashing exits once fn returns.
+func RunCrashing(t testing.TB, fn func()) Crash {
+	t.Helper()
+	if os.Getenv(EnvCrashing) == t.Name() {
+		fn()
+		os.Exit(0)
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run="+runPattern(t.Name()), "-test.count=1")
+	cmd.Env = append(os.Environ(), EnvCrashing+"="+t.Name())
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		t.Fatalf("nicetest: run %s in a child process: %v", t.Name(), err)
+		return Crash{}
+	}
+
+	crash := Crash{ExitCode: cmd.ProcessState.ExitCode(), Stderr: stderr.String()}
+	event, err := nice.ParseCrash(strings.NewReader(crash.Stderr))
+	if err == nil {
+		crash.Crashed, crash.Event = true, event
+	} else if !errors.Is(err, nice.ErrNoCrash) {
+		t.Errorf("nicetest: parse the crash output of %s: %v", t.Name(), err)
+	}
+	return crash
+}
+
+// runPattern matches exactly the test of the name for -test.run, level by level.
+func runPattern(name string) string {
+	levels := strings.Split(name, "/")
+	for i, level := range levels {
+		levels[i] = "^" + regexp.QuoteMeta(level) + "$"
+	}
+	return strings.Join(levels, "/")
+}
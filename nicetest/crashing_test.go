@@ -0,0 +1,41 @@
+package nicetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCrashing(t *testing.T) {
+	errHandled := errors.New("handled")
+	errFallthrough := errors.New("falls through")
+
+	t.Run("falls through", func(t *testing.T) {
+		crash := RunCrashing(t, func() {
+			defer nice.Tackle(errHandled).With(func(any) {})
+			panic(errFallthrough)
+		})
+
+		assert.True(t, crash.Crashed)
+		assert.Equal(t, 2, crash.ExitCode)
+		assert.Equal(t, "falls through", crash.Event.Message())
+		assert.NotEmpty(t, crash.Event.Stack)
+		assert.Contains(t, crash.Stderr, "panic: ")
+	})
+
+	t.Run("handled", func(t *testing.T) {
+		crash := RunCrashing(t, func() {
+			defer nice.Tackle(errHandled).With(func(any) {})
+			panic(errHandled)
+		})
+
+		assert.False(t, crash.Crashed)
+		assert.Equal(t, 0, crash.ExitCode)
+	})
+}
+
+func TestRunPattern(t *testing.T) {
+	assert.Equal(t, `^TestA$/^case_\(1\)$`, runPattern("TestA/case_(1)"))
+}
@@ -0,0 +1,67 @@
+package nicetest
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/antonyho/nice"
+)
+
+// update rewrites the golden files with the rendered reports instead of comparing them:
+//
+//	go test ./... -nicetest.update
+var update = flag.Bool("nicetest.update", false, "rewrite the golden files of nicetest.AssertGolden")
+
+// Normalize the event for deterministic rendering: its time is zeroed,
+// and its stack is normalized by NormalizeStack.
+func Normalize(event nice.PanicEvent) nice.PanicEvent {
+	event.Time = time.Time{}
+	return NormalizeStack(event)
+}
+
+// Render the normalized event as its crash report.
+func Render(event nice.PanicEvent) string {
+	return Normalize(event).String()
+}
+
+// RenderJSON renders the normalized event as indented JSON.
+func RenderJSON(event nice.PanicEvent) string {
+	encoded, err := json.MarshalIndent(Normalize(event), "", "  ")
+	if err != nil {
+		// Unreachable, the event encodes its artefact by type and message.
+		panic(err)
+	}
+	return string(encoded) + "\n"
+}
+
+// AssertGolden compares the rendered report to the golden file,
+// so changes to report formatting are caught in review.
+// Running the tests with -nicetest.update rewrites the golden file instead.
+//
+//	nicetest.AssertGolden(t, "testdata/timeout.golden", nicetest.Render(event))
+func AssertGolden(t testing.TB, golden string, rendered string) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+			t.Fatalf("nicetest: update %s: %v", golden, err)
+			return
+		}
+		if err := os.WriteFile(golden, []byte(rendered), 0o644); err != nil {
+			t.Fatalf("nicetest: update %s: %v", golden, err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("nicetest: read %s: %v (run with -nicetest.update to create it)", golden, err)
+		return
+	}
+	if string(expected) != rendered {
+		t.Errorf("nicetest: report differs from %s (run with -nicetest.update to accept it)\n--- expected\n%s\n--- rendered\n%s",
+			golden, expected, rendered)
+	}
+}
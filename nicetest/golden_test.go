@@ -0,0 +1,60 @@
+package nicetest_test
+
+import (
+	"errors"
+	"flag"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/nicetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func goldenEvent() nice.PanicEvent {
+	return nice.PanicEvent{
+		Artefact: errors.New("upstream timeout"),
+		Handled:  true,
+		Severity: nice.SeverityCritical,
+		Time:     time.Now(),
+		Stack: []nice.Frame{
+			{Function: "main.fetch", File: "/home/ci/src/app/fetch.go", Line: 12},
+			{Function: "main.main", File: "/home/ci/src/app/main.go", Line: 7},
+		},
+		Metadata: map[string]string{"request": "42"},
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	event := goldenEvent()
+	normalized := nicetest.Normalize(event)
+
+	assert.True(t, normalized.Time.IsZero())
+	assert.Equal(t, "fetch.go", normalized.Stack[0].File)
+	assert.Equal(t, "/home/ci/src/app/fetch.go", event.Stack[0].File, "the event is not modified")
+}
+
+func TestAssertGolden(t *testing.T) {
+	nicetest.AssertGolden(t, "testdata/event.golden", nicetest.Render(goldenEvent()))
+	nicetest.AssertGolden(t, "testdata/event.json.golden", nicetest.RenderJSON(goldenEvent()))
+
+	if flag.Lookup("nicetest.update").Value.String() == "true" {
+		return
+	}
+
+	t.Run("Mismatch", func(t *testing.T) {
+		tb := &recordingTB{TB: t}
+		event := goldenEvent()
+		event.Handled = false
+		nicetest.AssertGolden(tb, "testdata/event.golden", nicetest.Render(event))
+		assert.Len(t, tb.errors, 1)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		tb := &recordingTB{TB: t}
+		missing := filepath.Join(t.TempDir(), "missing.golden")
+		nicetest.AssertGolden(tb, missing, "report")
+		assert.Len(t, tb.fatals, 1)
+	})
+}
@@ -0,0 +1,89 @@
+/*
+Package nicetest provides the test helpers of nice.
+*/
+package nicetest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/antonyho/nice"
+)
+
+// Replay loads the events serialized in the event file, e.g. captured from production crash reports
+// by nice.FileHandler, and replays them through a policy of the pairs,
+// asserting which pair would have matched each event.
+// It returns the matched pair index of each event, or -1 if none.
+//
+// A record may name the expected pair index by "expect", or -1 for none.
+// Otherwise a recorded handled event is expected to be matched by any pair, and an unhandled one by none.
+//
+//	{"type":"*net.OpError","message":"dial tcp: i/o timeout","handled":true,"expect":1}
+//
+//	nicetest.Replay(t, "testdata/crashes.jsonl",
+//		nice.On(ErrNotFound, respondNotFound),
+//		nice.On(nice.MessageMatches(timeout), retry),
+//	)
+func Replay(t testing.TB, eventFile string, pairs ...nice.Pair) []int {
+	t.Helper()
+	records, err := readRecords(eventFile)
+	if err != nil {
+		t.Fatalf("nicetest: read %s: %v", eventFile, err)
+		return nil
+	}
+
+	policy := nice.Route(pairs...)
+	matches := make([]int, len(records))
+	for i, r := range records {
+		pair, matched := policy.Match(r.event)
+		matches[i] = pair
+		switch {
+		case r.Expect != nil && *r.Expect != pair:
+			t.Errorf("nicetest: event %d (%s: %s) matched pair %d, expected %d",
+				i, r.event.Type(), r.event.Message(), pair, *r.Expect)
+		case r.Expect == nil && r.event.Handled && !matched:
+			t.Errorf("nicetest: handled event %d (%s: %s) matched no pair",
+				i, r.event.Type(), r.event.Message())
+		case r.Expect == nil && !r.event.Handled && matched:
+			t.Errorf("nicetest: unhandled event %d (%s: %s) matched pair %d",
+				i, r.event.Type(), r.event.Message(), pair)
+		}
+	}
+	return matches
+}
+
+type record struct {
+	event  nice.PanicEvent
+	Expect *int `json:"expect"`
+}
+
+// readRecords decodes the JSON values of the file, one per line or concatenated.
+func readRecords(eventFile string) ([]record, error) {
+	f, err := os.Open(eventFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []record
+	decoder := json.NewDecoder(f)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); errors.Is(err, io.EOF) {
+			return records, nil
+		} else if err != nil {
+			return nil, err
+		}
+		var r record
+		if err := json.Unmarshal(raw, &r.event); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &r); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+}
@@ -0,0 +1,54 @@
+package nicetest_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/nicetest"
+	"github.com/stretchr/testify/assert"
+)
+
+var errNotFound = errors.New("not found")
+
+// recordingTB records the failures of the helpers under test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+	fatals []string
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// Fatalf records the failure; the helper under test shall return right after it.
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.fatals = append(r.fatals, fmt.Sprintf(format, args...))
+}
+
+func pairs() []nice.Pair {
+	return []nice.Pair{
+		nice.On(errNotFound, func(any) {}),
+		nice.On(nice.MessageMatches(regexp.MustCompile("timeout")), func(any) {}),
+		nice.On(reflect.TypeFor[error](), func(any) {}),
+	}
+}
+
+func TestReplay(t *testing.T) {
+	matches := nicetest.Replay(t, "testdata/crashes.jsonl", pairs()...)
+	assert.Equal(t, []int{0, 1, 2, -1}, matches)
+}
+
+func TestReplayMismatch(t *testing.T) {
+	tb := &recordingTB{TB: t}
+	matches := nicetest.Replay(tb, "testdata/crashes.jsonl", pairs()[1:]...)
+	assert.Equal(t, []int{1, 0, 1, -1}, matches)
+	assert.Equal(t, []string{
+		"nicetest: event 0 (*errors.errorString: not found) matched pair 1, expected 0",
+		"nicetest: event 2 (runtime.boundsError: runtime error: index out of range [3] with length 3) matched pair 1, expected 2",
+	}, tb.errors)
+}
@@ -0,0 +1,62 @@
+package nicetest
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/antonyho/nice"
+)
+
+var (
+	// goroutineID matches the header of a goroutine in a stack dump, e.g. "goroutine 17 [running]:".
+	goroutineID = regexp.MustCompile(`goroutine \d+`)
+	// pcOffset matches the offset of a frame in a stack dump, e.g. " +0x1d".
+	pcOffset = regexp.MustCompile(` \+0x[0-9a-f]+`)
+	// address matches pointers and program counters, e.g. "0xc000012345".
+	address = regexp.MustCompile(`0x[0-9a-f]+`)
+	// absolutePath matches the absolute path of a file in a stack dump, e.g. "\t/home/ci/src/app/main.go:7".
+	absolutePath = regexp.MustCompile(`(?m)^\t\S*/([^/\s]+\.go):`)
+)
+
+// NormalizeStack returns the event with its stack stable across machines and Go versions,
+// for assertions on stack-containing reports:
+//   - files are reduced to their base names,
+//   - the lines of standard library frames are zeroed,
+//   - addresses and goroutine IDs are replaced by placeholders in the functions
+//     and in stack dumps carried by the metadata.
+func NormalizeStack(event nice.PanicEvent) nice.PanicEvent {
+	if event.Stack != nil {
+		stack := make([]nice.Frame, len(event.Stack))
+		for i, f := range event.Stack {
+			f.Function = address.ReplaceAllString(f.Function, "0x?")
+			f.File = path.Base(strings.ReplaceAll(f.File, `\`, "/"))
+			// The lines of the standard library change across Go versions.
+			if f.Standard() {
+				f.Line = 0
+			}
+			stack[i] = f
+		}
+		event.Stack = stack
+	}
+	if event.Metadata != nil {
+		metadata := make(map[string]string, len(event.Metadata))
+		for k, v := range event.Metadata {
+			metadata[k] = normalizeDump(v)
+		}
+		event.Metadata = metadata
+	}
+	return event
+}
+
+// normalizeDump normalizes the stack dump in the text, as printed by runtime/debug.Stack.
+// Text without a dump is left as it is.
+func normalizeDump(text string) string {
+	if !goroutineID.MatchString(text) {
+		return text
+	}
+	text = goroutineID.ReplaceAllString(text, "goroutine N")
+	text = pcOffset.ReplaceAllString(text, "")
+	text = address.ReplaceAllString(text, "0x?")
+	return absolutePath.ReplaceAllString(text, "\t$1:")
+}
@@ -0,0 +1,42 @@
+package nicetest_test
+
+import (
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/antonyho/nice/nicetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeStack(t *testing.T) {
+	event := nice.PanicEvent{
+		Stack: []nice.Frame{
+			{Function: "github.com/acme/app.(*Server).fetch", File: "/home/ci/src/app/fetch.go", Line: 12},
+			{Function: "main.main", File: `C:\src\app\main.go`, Line: 7},
+			{Function: "net/http.HandlerFunc.ServeHTTP", File: "/usr/local/go/src/net/http/server.go", Line: 2220},
+			{Function: "runtime.main", File: "/usr/local/go/src/runtime/proc.go", Line: 283},
+		},
+		Metadata: map[string]string{
+			"request": "42",
+			"dump": "goroutine 17 [running]:\n" +
+				"main.fetch(0xc000012345)\n" +
+				"\t/home/ci/src/app/fetch.go:12 +0x1d\n",
+		},
+	}
+
+	normalized := nicetest.NormalizeStack(event)
+
+	assert.Equal(t, []nice.Frame{
+		{Function: "github.com/acme/app.(*Server).fetch", File: "fetch.go", Line: 12},
+		{Function: "main.main", File: "main.go", Line: 7},
+		{Function: "net/http.HandlerFunc.ServeHTTP", File: "server.go"},
+		{Function: "runtime.main", File: "proc.go"},
+	}, normalized.Stack)
+	assert.Equal(t, map[string]string{
+		"request": "42",
+		"dump": "goroutine N [running]:\n" +
+			"main.fetch(0x?)\n" +
+			"\tfetch.go:12\n",
+	}, normalized.Metadata)
+	assert.Equal(t, "/home/ci/src/app/fetch.go", event.Stack[0].File, "the event is not modified")
+}
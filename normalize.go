@@ -0,0 +1,47 @@
+package nice
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+var normalizer atomic.Pointer[func(artefact any) any]
+
+// SetNormalizer sets the func canonicalizing the artefacts before matching,
+// so odd panic values from third-party code are converted in one place,
+// e.g. gRPC status panics into a standard error type.
+// The handle func receives the normalized artefact, while the events keep the original one.
+// A panicking normalizer leaves the artefact as it is. Passing nil removes the normalizer.
+//
+//	nice.SetNormalizer(func(artefact any) any {
+//		if s, ok := artefact.(*status.Status); ok {
+//			return &RPCError{Code: s.Code(), Message: s.Message()}
+//		}
+//		return artefact
+//	})
+func SetNormalizer(normalize func(artefact any) any) {
+	if normalize == nil {
+		normalizer.Store(nil)
+		return
+	}
+	normalizer.Store(&normalize)
+}
+
+// normalize the artefact by the normalizer, if any.
+func normalize(artefact any) any {
+	n := normalizer.Load()
+	if n == nil {
+		return artefact
+	}
+	return normalizeBy(*n, artefact)
+}
+
+func normalizeBy(normalize func(artefact any) any, artefact any) (normalized any) {
+	defer func() {
+		if r := recover(); r != nil {
+			logError(fmt.Errorf("normalizer panicked: %v", r))
+			normalized = artefact
+		}
+	}()
+	return normalize(artefact)
+}
@@ -0,0 +1,57 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type statusPanic struct{ code int }
+
+func TestSetNormalizer(t *testing.T) {
+	errUnavailable := errors.New("unavailable")
+	normalized := 0
+	SetNormalizer(func(artefact any) any {
+		normalized++
+		if s, ok := artefact.(statusPanic); ok && s.code == 14 {
+			return errUnavailable
+		}
+		return artefact
+	})
+	t.Cleanup(func() { SetNormalizer(nil) })
+
+	t.Run("With", func(t *testing.T) {
+		var received any
+		func() {
+			defer Tackle(errUnavailable).With(func(artefact any) { received = artefact })
+			panic(statusPanic{code: 14})
+		}()
+		assert.Equal(t, errUnavailable, received)
+	})
+
+	t.Run("Registered", func(t *testing.T) {
+		cleanRegistry(t)
+		var received any
+		Register(errors.New("other"), func(any) {})
+		Register(errUnavailable, func(artefact any) { received = artefact })
+		normalized = 0
+
+		event := Dispatch(context.Background(), statusPanic{code: 14})
+
+		assert.Equal(t, 1, normalized, "the artefact is normalized once per dispatch")
+		assert.True(t, event.Handled)
+		assert.Equal(t, statusPanic{code: 14}, event.Artefact, "the event keeps the original artefact")
+		assert.Equal(t, errUnavailable, received)
+	})
+
+	t.Run("Not normalized", func(t *testing.T) {
+		assert.False(t, Tackle(errUnavailable).matches(statusPanic{code: 5}))
+	})
+
+	t.Run("Panicking normalizer", func(t *testing.T) {
+		SetNormalizer(func(any) any { panic("broken normalizer") })
+		assert.True(t, Tackle(errUnavailable).matches(errUnavailable))
+	})
+}
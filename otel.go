@@ -0,0 +1,106 @@
+package nice
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// LogEmitter emits log records to the OpenTelemetry Logs SDK.
+// It is implemented by a small adapter over a log.Logger of go.opentelemetry.io/otel/log,
+// so this package does not depend on OpenTelemetry:
+//
+//	type emitter struct{ logger log.Logger }
+//
+//	func (e emitter) Emit(ctx context.Context, r nice.LogRecord) {
+//		var record log.Record
+//		record.SetTimestamp(r.Time)
+//		record.SetSeverity(log.Severity(r.SeverityNumber))
+//		record.SetSeverityText(r.SeverityText)
+//		record.SetBody(log.StringValue(r.Body))
+//		for _, a := range r.Attributes {
+//			record.AddAttributes(log.String(a.Key, a.Value.String()))
+//		}
+//		e.logger.Emit(ctx, record)
+//	}
+//
+// The SDK correlates the record with the span carried by ctx, if any.
+type LogEmitter interface {
+	Emit(ctx context.Context, record LogRecord)
+}
+
+// LogRecord of a PanicEvent according to the OpenTelemetry log data model.
+type LogRecord struct {
+	Time time.Time
+	// SeverityNumber is in the range of the data model, e.g. 17 for ERROR and 21 for FATAL.
+	SeverityNumber int
+	SeverityText   string
+	Body           string
+	// Attributes follow the semantic conventions of exceptions and code locations.
+	Attributes []slog.Attr
+}
+
+// OTelLogHandler returns an event handle func emitting the event through the emitter,
+// in the context of the recovery point, see PanicEvent.Context.
+// Register it with NeedsStack for the code attributes and the stack trace.
+func OTelLogHandler(emitter LogEmitter) func(event PanicEvent) {
+	return func(event PanicEvent) {
+		if event.SampledOut() {
+			return
+		}
+		emitter.Emit(event.Context(), newLogRecord(event))
+	}
+}
+
+func newLogRecord(event PanicEvent) LogRecord {
+	level := severityLevel(event.Severity)
+	attrs := []slog.Attr{
+		slog.String("exception.type", event.Type()),
+		slog.String("exception.message", event.Message()),
+		slog.Bool("nice.handled", event.Handled),
+	}
+	if len(event.Stack) > 0 {
+		frame := event.Stack[0]
+		var trace strings.Builder
+		for _, f := range event.Stack {
+			trace.WriteString(f.String())
+			trace.WriteString("\n")
+		}
+		attrs = append(attrs,
+			slog.String("code.function", frame.Function),
+			slog.String("code.filepath", frame.File),
+			slog.Int("code.lineno", frame.Line),
+			slog.String("exception.stacktrace", trace.String()),
+		)
+	}
+	for _, k := range sortedKeys(event.Metadata) {
+		attrs = append(attrs, slog.String(k, event.Metadata[k]))
+	}
+	for _, k := range sortedKeys(event.Tags) {
+		attrs = append(attrs, slog.String("nice.tag."+k, event.Tags[k]))
+	}
+	return LogRecord{
+		Time: event.Time,
+		// The levels of slog are aligned with the data model, 9 apart.
+		SeverityNumber: int(level) + 9,
+		SeverityText:   otelSeverityText(level),
+		Body:           "panic tackled: " + event.Message(),
+		Attributes:     attrs,
+	}
+}
+
+func otelSeverityText(level slog.Level) string {
+	switch {
+	case level > slog.LevelError:
+		return "FATAL"
+	case level == slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARN"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
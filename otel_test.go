@@ -0,0 +1,71 @@
+package nice
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockEmitter struct {
+	ctx     context.Context
+	records []LogRecord
+}
+
+func (e *mockEmitter) Emit(ctx context.Context, record LogRecord) {
+	e.ctx = ctx
+	e.records = append(e.records, record)
+}
+
+func TestOTelLogHandler(t *testing.T) {
+	emitter := &mockEmitter{}
+	OTelLogHandler(emitter)(mockEvent())
+
+	if assert.Len(t, emitter.records, 1) {
+		record := emitter.records[0]
+		assert.Equal(t, 13, record.SeverityNumber)
+		assert.Equal(t, "WARN", record.SeverityText)
+		assert.Equal(t, "panic tackled: mock error", record.Body)
+		attrs := make(map[string]slog.Value)
+		for _, a := range record.Attributes {
+			attrs[a.Key] = a.Value
+		}
+		assert.Equal(t, "*errors.errorString", attrs["exception.type"].String())
+		assert.Equal(t, "main.run", attrs["code.function"].String())
+		assert.Equal(t, int64(9), attrs["code.lineno"].Int64())
+		assert.Equal(t, "42", attrs["request"].String())
+	}
+	assert.Equal(t, context.Background(), emitter.ctx)
+}
+
+func TestOTelLogHandlerContext(t *testing.T) {
+	cleanRegistry(t)
+	type spanKey struct{}
+	ctx := context.WithValue(context.Background(), spanKey{}, "span")
+	emitter := &mockEmitter{}
+	RegisterEvent(reflect.TypeFor[string](), OTelLogHandler(emitter))
+
+	func() {
+		defer GuardContext(ctx)
+		panic("traced")
+	}()
+
+	assert.Equal(t, "span", emitter.ctx.Value(spanKey{}), "The record is emitted in the context of the recovery point.")
+	if assert.Len(t, emitter.records, 1) {
+		assert.Equal(t, 17, emitter.records[0].SeverityNumber)
+	}
+}
+
+func TestSeverityNumber(t *testing.T) {
+	for severity, number := range map[Severity]int{
+		SeverityDebug:    5,
+		SeverityInfo:     9,
+		SeverityWarning:  13,
+		SeverityError:    17,
+		SeverityCritical: 21,
+	} {
+		assert.Equal(t, number, newLogRecord(PanicEvent{Severity: severity}).SeverityNumber, severity.String())
+	}
+}
@@ -0,0 +1,13 @@
+package nice
+
+// Pair of a target and its handle func.
+type Pair struct {
+	// Target is anything accepted by Tackle, or a Handler.
+	Target any
+	Handle func(artefact any)
+}
+
+// On pairs the target with the handle func.
+func On(target any, handle func(artefact any)) Pair {
+	return Pair{Target: target, Handle: handle}
+}
@@ -0,0 +1,99 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrPluginDisabled is returned by the calls of a plugin disabled after its repeated panics.
+var ErrPluginDisabled = errors.New("nice: plugin disabled")
+
+// Plugin protects the calls of a host into a plugin, e.g. loaded by plugin.Open
+// or registering its handlers, as returned by NewPlugin.
+type Plugin struct {
+	name    string
+	version string
+
+	mu       sync.Mutex
+	budget   *Budget
+	disabled bool
+}
+
+// PluginOption configures the Plugin of NewPlugin.
+type PluginOption func(*Plugin)
+
+// DisableAfter disables the plugin once it panicked the number of times within the window.
+// A plugin is never disabled by default.
+func DisableAfter(panics int, window time.Duration) PluginOption {
+	return func(p *Plugin) { p.budget = NewBudget(panics-1, window) }
+}
+
+// NewPlugin returns the protected invocation layer of the plugin of the name and version.
+//
+//	p := nice.NewPlugin(name, version, nice.DisableAfter(3, time.Hour))
+//	err := p.Call(ctx, func(ctx context.Context) { handler.Serve(ctx, req) })
+func NewPlugin(name, version string, opts ...PluginOption) *Plugin {
+	p := &Plugin{name: name, version: version}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Call fn of the plugin, returning its panic as a *PanicError as Protect does.
+// The panic is dispatched to the handlers carried by the context, then to the globally registered handlers
+// and reporters, attributed to the plugin by its name and version recorded as plugin and plugin_version
+// in the event metadata. The event of the panic which disables the plugin also records plugin_disabled.
+// Once the plugin is disabled, Call returns ErrPluginDisabled without calling fn.
+func (p *Plugin) Call(ctx context.Context, fn func(ctx context.Context)) (err error) {
+	if p.Disabled() {
+		return fmt.Errorf("%w: %s %s", ErrPluginDisabled, p.name, p.version)
+	}
+	defer func() {
+		if RecoveryDisabled {
+			return
+		}
+		if artefact := recover(); artefact != nil {
+			metadata := map[string]string{"plugin": p.name, "plugin_version": p.version}
+			if p.panicked() {
+				metadata["plugin_disabled"] = "true"
+			}
+			event := recoverWith(ctx, artefact, metadata, "Plugin")
+			err = &PanicError{Value: artefact, Stack: event.Stack}
+		}
+	}()
+	fn(ctx)
+	return nil
+}
+
+// Disabled tells whether the plugin is disabled after its repeated panics.
+func (p *Plugin) Disabled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.disabled
+}
+
+// Enable the plugin again, e.g. once upgraded, with its panics counted over.
+func (p *Plugin) Enable() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.disabled = false
+	if p.budget != nil {
+		p.budget = NewBudget(p.budget.limit, p.budget.window)
+	}
+}
+
+// panicked counts the panic against the budget of the plugin, and reports whether it disabled the plugin.
+func (p *Plugin) panicked() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.budget == nil || p.disabled {
+		return false
+	}
+	p.budget.Report(PanicEvent{})
+	p.disabled = p.budget.Exhausted()
+	return p.disabled
+}
@@ -0,0 +1,48 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlugin(t *testing.T) {
+	events := reported(t)
+	p := NewPlugin("thumbnails", "1.2.0", DisableAfter(2, time.Hour))
+	calls := 0
+	panicking := func(context.Context) {
+		calls++
+		panic("bad image")
+	}
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, p.Call(context.Background(), panicking), &panicErr)
+	assert.False(t, p.Disabled())
+	assert.ErrorAs(t, p.Call(context.Background(), panicking), &panicErr)
+	assert.True(t, p.Disabled(), "disabled after the second panic")
+	assert.ErrorIs(t, p.Call(context.Background(), panicking), ErrPluginDisabled)
+	assert.Equal(t, 2, calls, "a disabled plugin is not called")
+
+	if assert.Len(t, events.events, 2) {
+		assert.Equal(t, map[string]string{"plugin": "thumbnails", "plugin_version": "1.2.0"}, events.events[0].Metadata)
+		assert.Equal(t, "true", events.events[1].Metadata["plugin_disabled"])
+	}
+
+	p.Enable()
+	assert.NoError(t, p.Call(context.Background(), func(context.Context) {}))
+	assert.ErrorAs(t, p.Call(context.Background(), panicking), &panicErr)
+	assert.False(t, p.Disabled(), "the panics are counted over once enabled")
+}
+
+func TestPluginNeverDisabled(t *testing.T) {
+	cleanRegistry(t)
+	p := NewPlugin("thumbnails", "1.2.0")
+	errImage := errors.New("bad image")
+	for range 5 {
+		assert.ErrorIs(t, p.Call(context.Background(), func(context.Context) { panic(errImage) }), errImage)
+	}
+	assert.False(t, p.Disabled())
+}
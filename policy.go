@@ -0,0 +1,109 @@
+package nice
+
+// Policy routes panics to handle funcs by target, within a single recover.
+// With multiple deferred Handler.With, only the innermost recover gets the panic;
+// a Policy covers them all in one deferred call.
+type Policy struct {
+	pairs    []Pair
+	handlers []Handler
+	fallback func(artefact any)
+}
+
+// Route returns a Policy for the pairs, matched in order.
+// The first matched pair handles the artefact.
+// Pairs are taken in order, instead of as a map of target to handle func,
+// so the routing does not depend on map iteration order.
+//
+//	defer nice.Route(
+//		nice.On(ErrNotFound, respondNotFound),
+//		nice.On(nice.Type[*ValidationError](), respondInvalid),
+//		nice.On(reflect.TypeFor[error](), respondInternal),
+//	).Guard()
+func Route(pairs ...Pair) Policy {
+	p := Policy{
+		pairs:    make([]Pair, len(pairs)),
+		handlers: make([]Handler, len(pairs)),
+	}
+	for i, pair := range pairs {
+		p.pairs[i] = pair
+		p.handlers[i] = toHandler(pair.Target)
+	}
+	return p
+}
+
+// Default sets the handle func for the artefact matched by no pair.
+// With a default, a panic never falls through the Policy.
+func (p Policy) Default(handle func(artefact any)) Policy {
+	p.fallback = handle
+	return p
+}
+
+// Guard recovers panic and calls the handle func of the first matched pair.
+// The panic falls through if no pair matches.
+// It shall be deferred directly.
+func (p Policy) Guard() {
+	if RecoveryDisabled {
+		return
+	}
+	if artefact := recover(); artefact != nil {
+		p.tackle(artefact)
+	}
+}
+
+// Handle recovers panic and handles it by the policy, as Policy.Guard.
+// A hot path protected against several targets pays for exactly one defer,
+// instead of one deferred Handler.With per target.
+// It shall be deferred directly.
+//
+//	var policy = nice.Route(
+//		nice.On(io.EOF, stop),
+//		nice.On(nice.Type[*ParseError](), skip),
+//	)
+//
+//	func decode(r io.Reader) {
+//		defer nice.Handle(policy)
+//		...
+//	}
+func Handle(p Policy) {
+	if RecoveryDisabled {
+		return
+	}
+	if artefact := recover(); artefact != nil {
+		p.tackle(artefact)
+	}
+}
+
+func (p Policy) tackle(artefact any) {
+	logger := debugLogger.Load()
+	debugRecovered(logger, "Policy", artefact)
+	normalized := normalize(artefact)
+	for i, h := range p.handlers {
+		handle := p.pairs[i].Handle
+		if resolved, matched := h.resolveNormalized(normalized, debugTracer(logger, "Policy")); matched {
+			if logger != nil {
+				debugOutcome(logger, "Policy", funcName(handle), true)
+			}
+			if handlerPanic := runHandle(handle, resolved); handlerPanic != nil {
+				handlerPanicked(artefact, handlerPanic)
+			}
+			reraise(artefact)
+			return
+		}
+	}
+	if p.fallback != nil {
+		if logger != nil {
+			debugOutcome(logger, "Policy", funcName(p.fallback), true)
+		}
+		if handlerPanic := runHandle(p.fallback, artefact); handlerPanic != nil {
+			handlerPanicked(artefact, handlerPanic)
+		}
+		reraise(artefact)
+		return
+	}
+	debugOutcome(logger, "Policy", "", false)
+	if observingUnhandled() {
+		unhandled(stacked(newEvent(artefact)), "Policy")
+	}
+
+	panic(artefact)
+}
@@ -0,0 +1,86 @@
+package nice_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoute(t *testing.T) {
+	errNotFound := errors.New("not found")
+	routed := func(artefact any) (executed []string) {
+		defer nice.Route(
+			nice.On(errNotFound, func(any) { executed = append(executed, "not found") }),
+			nice.On(reflect.TypeFor[string](), func(any) { executed = append(executed, "string") }),
+			nice.On(reflect.TypeFor[error](), func(any) { executed = append(executed, "error") }),
+		).Guard()
+		panic(artefact)
+	}
+
+	assert.Equal(t, []string{"not found"}, routed(errNotFound))
+	assert.Equal(t, []string{"string"}, routed("message"))
+	assert.Equal(t, []string{"error"}, routed(errors.New("other")), "The first matched pair handles.")
+	assert.Panics(t, func() { routed(7) }, "Unhandled panic falls through.")
+}
+
+func TestRouteDefault(t *testing.T) {
+	var executed string
+	assert.NotPanics(t, func() {
+		defer nice.Route(
+			nice.On(reflect.TypeFor[string](), func(any) { executed = "string" }),
+		).Default(func(any) { executed = "default" }).Guard()
+		panic(7)
+	})
+	assert.Equal(t, "default", executed)
+}
+
+func TestHandle(t *testing.T) {
+	var executed string
+	policy := nice.Route(
+		nice.On(reflect.TypeFor[string](), func(any) { executed = "string" }),
+		nice.On(reflect.TypeFor[int](), func(any) { executed = "int" }),
+	)
+
+	assert.NotPanics(t, func() {
+		defer nice.Handle(policy)
+		panic(7)
+	})
+	assert.Equal(t, "int", executed)
+	assert.Panics(t, func() {
+		defer nice.Handle(policy)
+		panic(errors.New("unmatched"))
+	})
+}
+
+func TestPolicyMatch(t *testing.T) {
+	errNotFound := errors.New("not found")
+	policy := nice.Route(
+		nice.On(errNotFound, func(any) {}),
+		nice.On(reflect.TypeFor[string](), func(any) {}),
+		nice.On(reflect.TypeFor[error](), func(any) {}),
+	)
+
+	for name, tc := range map[string]struct {
+		artefact any
+		pair     int
+		matched  bool
+	}{
+		"Error":                  {errNotFound, 0, true},
+		"Type":                   {"message", 1, true},
+		"Generic error":          {errors.New("other"), 2, true},
+		"None":                   {42, -1, false},
+		"Recorded error":         {nice.Recorded{TypeName: "*errors.errorString", Text: "not found"}, 0, true},
+		"Recorded type":          {nice.Recorded{TypeName: "string", Text: "message"}, 1, true},
+		"Recorded generic error": {nice.Recorded{TypeName: "*fs.PathError", Text: "open x: no such file"}, 2, true},
+		"Recorded builtin type":  {nice.Recorded{TypeName: "int", Text: "42"}, -1, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pair, matched := policy.Match(nice.PanicEvent{Artefact: tc.artefact})
+			assert.Equal(t, tc.pair, pair)
+			assert.Equal(t, tc.matched, matched)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package nice
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+)
+
+// HeapProfileHandler returns an event handle func writing a pprof heap profile into dir,
+// alongside the crash report of the event, e.g. for allocation related runtime errors.
+// The files are named heap-<time>.pprof and heap-<time>.txt.
+// It writes at most once per interval, so a crash loop does not fill the disk.
+// Register it with NeedsStack for the stack in the report.
+//
+//	nice.RegisterEvent(nice.MessageMatches(regexp.MustCompile("out of memory")),
+//		nice.HeapProfileHandler("/var/crash", time.Minute), nice.NeedsStack())
+func HeapProfileHandler(dir string, interval time.Duration) func(event PanicEvent) {
+	return profileHandler("heap", dir, interval, func(f *os.File) error {
+		// The heap profile is as of the last garbage collection.
+		runtime.GC()
+		return pprof.Lookup("heap").WriteTo(f, 0)
+	})
+}
+
+// GoroutineProfileHandler returns an event handle func writing a pprof goroutine profile into dir,
+// alongside the crash report of the event, as HeapProfileHandler,
+// to diagnose panics correlating with goroutine leaks or stuck workers.
+// The files are named goroutine-<time>.pprof and goroutine-<time>.txt.
+func GoroutineProfileHandler(dir string, interval time.Duration) func(event PanicEvent) {
+	return profileHandler("goroutine", dir, interval, func(f *os.File) error {
+		return pprof.Lookup("goroutine").WriteTo(f, 0)
+	})
+}
+
+// profileHandler writes the profile and the crash report at most once per interval.
+func profileHandler(name, dir string, interval time.Duration, write func(f *os.File) error) func(event PanicEvent) {
+	var mu sync.Mutex
+	var last time.Time
+	return func(event PanicEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		now := clockNow()
+		if !last.IsZero() && now.Sub(last) < interval {
+			return
+		}
+		last = now
+
+		base := filepath.Join(dir, name+"-"+now.UTC().Format("20060102T150405.000000000"))
+		if err := writeProfile(base+".pprof", write); err != nil {
+			logError(fmt.Errorf("write %s profile: %w", name, err))
+			return
+		}
+		if err := os.WriteFile(base+".txt", []byte(event.String()), 0o644); err != nil {
+			logError(fmt.Errorf("write crash report: %w", err))
+		}
+	}
+}
+
+func writeProfile(path string, write func(f *os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
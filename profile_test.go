@@ -0,0 +1,44 @@
+package nice
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeapProfileHandler(t *testing.T) {
+	dir := t.TempDir()
+	handle := HeapProfileHandler(dir, time.Hour)
+
+	handle(mockEvent())
+	handle(mockEvent())
+
+	profiles, _ := filepath.Glob(filepath.Join(dir, "heap-*.pprof"))
+	reports, _ := filepath.Glob(filepath.Join(dir, "heap-*.txt"))
+	assert.Len(t, profiles, 1, "At most once per interval.")
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, strings.TrimSuffix(profiles[0], ".pprof"), strings.TrimSuffix(reports[0], ".txt"))
+		report, err := os.ReadFile(reports[0])
+		assert.NoError(t, err)
+		assert.Contains(t, string(report), "panic: mock error")
+	}
+	if info, err := os.Stat(profiles[0]); assert.NoError(t, err) {
+		assert.NotZero(t, info.Size())
+	}
+}
+
+func TestGoroutineProfileHandler(t *testing.T) {
+	dir := t.TempDir()
+	handle := GoroutineProfileHandler(dir, 0)
+
+	handle(mockEvent())
+	time.Sleep(time.Microsecond)
+	handle(mockEvent())
+
+	profiles, _ := filepath.Glob(filepath.Join(dir, "goroutine-*.pprof"))
+	assert.Len(t, profiles, 2, "Every time without interval.")
+}
@@ -0,0 +1,202 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// MaxProtectDepth is the number of protected calls, by Protect, Retry and Supervise,
+// which may be nested on a goroutine.
+const MaxProtectDepth = 32
+
+// DefaultRestartDelay is the delay of Supervise between the restarts of a panicking func, unless set by WithBackoff.
+const DefaultRestartDelay = 100 * time.Millisecond
+
+// ErrProtectDepth is returned by the protected calls nested deeper than MaxProtectDepth,
+// e.g. by misconfigured mutual wrapping, rather than exhausting the stack.
+var ErrProtectDepth = errors.New("nice: protected calls nested too deep")
+
+// PanicError is the error of a panic recovered by a protected call.
+type PanicError struct {
+	// Value passed to panic.
+	Value any
+	// Stack of the panicking goroutine, starting at the panic site.
+	Stack []Frame
+}
+
+// Error formats the panic value by Stringify.
+func (e *PanicError) Error() string {
+	return "panic: " + Stringify(e.Value, MaxBytes(1024))
+}
+
+// Unwrap returns the panic value if it is an error, for errors.Is and errors.As.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Protect calls fn, returning its panic as a *PanicError instead of crashing,
+// e.g. at the boundary of a plugin or a job.
+//
+//	if err := nice.Protect(job.Run); err != nil {
+//		log.Printf("job failed: %v", err)
+//	}
+func Protect(fn func()) error {
+	if depth := protectDepth(); depth >= MaxProtectDepth {
+		return fmt.Errorf("%w: %d nested protected calls, e.g. Retry and Supervise wrapping each other", ErrProtectDepth, depth)
+	}
+	return runProtected(fn)
+}
+
+// ProtectResult calls fn as Protect, returning its result too.
+func ProtectResult[T any](fn func() T) (result T, err error) {
+	err = Protect(func() { result = fn() })
+	return result, err
+}
+
+// ProtectResult2 calls fn as Protect, returning its two results too,
+// so a func returning a value and an error is guarded with both typed.
+//
+//	doc, decodeErr, err := nice.ProtectResult2(func() (*Document, error) { return decode(raw) })
+func ProtectResult2[A, B any](fn func() (A, B)) (a A, b B, err error) {
+	err = Protect(func() { a, b = fn() })
+	return a, b, err
+}
+
+// ProtectResult3 calls fn as Protect, returning its three results too.
+func ProtectResult3[A, B, C any](fn func() (A, B, C)) (a A, b B, c C, err error) {
+	err = Protect(func() { a, b, c = fn() })
+	return a, b, c, err
+}
+
+// ProtectAttempt calls fn for an attempt of a retry loop, e.g. of an HTTP client,
+// so its panic fails the attempt with a *PanicError, which the loop retries as any other error.
+// The panic is dispatched as by Dispatch, with the attempt number recorded as "attempt" in the event metadata.
+//
+//	resp, err := nice.ProtectAttempt(ctx, attempt, func(ctx context.Context) (*http.Response, error) {
+//		return send(ctx, req)
+//	})
+func ProtectAttempt[T any](ctx context.Context, attempt int, fn func(ctx context.Context) (T, error)) (result T, err error) {
+	defer func() {
+		if RecoveryDisabled {
+			return
+		}
+		if artefact := recover(); artefact != nil {
+			event := recoverWith(ctx, artefact, map[string]string{"attempt": strconv.Itoa(attempt)}, "ProtectAttempt")
+			var zero T
+			result, err = zero, &PanicError{Value: artefact, Stack: event.Stack}
+		}
+	}()
+	return fn(ctx)
+}
+
+// Retry calls fn as Protect until it returns without panicking, up to the attempts,
+// returning the panic of the last attempt.
+// There is no delay between the attempts, unless set by WithBackoff.
+// With RetryContext, it gives up once the context is done, returning the panic and the error of the context.
+//
+//	err := nice.Retry(5, sync, nice.WithBackoff(nice.Jittered(nice.ExponentialBackoff{Initial: time.Second}, 0.2)))
+func Retry(attempts int, fn func(), opts ...RetryOption) error {
+	c := newRetryConfig(ConstantBackoff(0), opts)
+	var err error
+	for attempt := range max(attempts, 1) {
+		if attempt > 0 {
+			if sleepErr := Sleep(c.ctx, c.backoff, attempt); sleepErr != nil {
+				return errors.Join(err, sleepErr)
+			}
+		}
+		if err = Protect(fn); err == nil || errors.Is(err, ErrProtectDepth) {
+			return err
+		}
+	}
+	return err
+}
+
+// Supervise calls fn as Protect, and restarts it whenever it panics, until the context is done.
+// The restarts wait DefaultRestartDelay, unless set by WithBackoff; the backoff starts over once fn ran for a minute.
+// Each panic is reported to the reporters.
+// It returns nil once fn returns without panicking, the error of the context, or ErrProtectDepth.
+//
+//	go nice.Supervise(ctx, consumer.Run)
+func Supervise(ctx context.Context, fn func(ctx context.Context), opts ...RetryOption) error {
+	c := newRetryConfig(ConstantBackoff(DefaultRestartDelay), opts)
+	for restart := 1; ; restart++ {
+		start := clockNow()
+		err := Protect(func() { fn(ctx) })
+		if errors.Is(err, ErrProtectDepth) {
+			// Restarting would hit the depth again.
+			return err
+		}
+		var panicErr *PanicError
+		if errors.As(err, &panicErr) {
+			snapshot := loadRegistry()
+			report(PanicEvent{Artefact: panicErr.Value, Time: clockNow(), Stack: panicErr.Stack, ctx: ctx},
+				snapshot.reporters, snapshot.config)
+		}
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if clockNow().Sub(start) >= superviseReset {
+			restart = 1
+		}
+		if err := Sleep(ctx, c.backoff, restart); err != nil {
+			return err
+		}
+	}
+}
+
+// superviseReset is how long fn shall run for Supervise to start its backoff over.
+const superviseReset = time.Minute
+
+// runProtected is the frame of a protected call, counted by protectDepth.
+//
+//go:noinline
+func runProtected(fn func()) (err error) {
+	defer func() {
+		if RecoveryDisabled {
+			return
+		}
+		if artefact := recover(); artefact != nil {
+			event := stacked(newEvent(artefact))
+			err = &PanicError{Value: artefact, Stack: event.Stack}
+		}
+	}()
+	fn()
+	return nil
+}
+
+// protectFunc is the name of runProtected in stacks.
+var protectFunc = runtime.FuncForPC(reflect.ValueOf(runProtected).Pointer()).Name()
+
+// protectDepth counts the protected calls on the stack of the calling goroutine, up to MaxProtectDepth.
+func protectDepth() int {
+	const chunk = 256
+	var pcs [chunk]uintptr
+	depth := 0
+	for skip := 3; ; skip += chunk {
+		n := runtime.Callers(skip, pcs[:])
+		frames := runtime.CallersFrames(pcs[:n])
+		for {
+			frame, more := frames.Next()
+			if frame.Function == protectFunc {
+				if depth++; depth == MaxProtectDepth {
+					return depth
+				}
+			}
+			if !more {
+				break
+			}
+		}
+		if n < chunk {
+			return depth
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtect(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	assert.NoError(t, Protect(func() {}))
+
+	err := Protect(func() { panic(errBoom) })
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, errBoom, panicErr.Value)
+	assert.ErrorIs(t, err, errBoom)
+	assert.Equal(t, "panic: boom", err.Error())
+	assert.True(t, strings.HasPrefix(panicErr.Stack[0].Function, "github.com/antonyho/nice.TestProtect"),
+		"the stack starts at the panic site: %s", panicErr.Stack[0].Function)
+
+	result, err := ProtectResult(func() int { return 42 })
+	assert.NoError(t, err)
+	assert.Equal(t, 42, result)
+
+	_, err = ProtectResult(func() int { panic("no result") })
+	assert.EqualError(t, err, "panic: no result")
+}
+
+func TestProtectResults(t *testing.T) {
+	n, parseErr, err := ProtectResult2(func() (int64, error) { return strconv.ParseInt("12", 10, 64) })
+	assert.NoError(t, err)
+	assert.NoError(t, parseErr)
+	assert.Equal(t, int64(12), n)
+
+	n, _, err = ProtectResult2(func() (int64, error) { panic("no results") })
+	assert.EqualError(t, err, "panic: no results")
+	assert.Zero(t, n)
+
+	s, ok, count, err := ProtectResult3(func() (string, bool, int) { return "a", true, 1 })
+	assert.NoError(t, err)
+	assert.Equal(t, "a", s)
+	assert.True(t, ok)
+	assert.Equal(t, 1, count)
+
+	_, _, _, err = ProtectResult3(func() (string, bool, int) { panic("no results") })
+	assert.EqualError(t, err, "panic: no results")
+}
+
+func TestProtectAttempt(t *testing.T) {
+	events := reported(t)
+
+	n, err := ProtectAttempt(context.Background(), 2, func(context.Context) (int, error) { panic("flaky") })
+	assert.EqualError(t, err, "panic: flaky")
+	assert.Zero(t, n)
+	if assert.Len(t, events.events, 1) {
+		assert.Equal(t, "2", events.events[0].Metadata["attempt"])
+	}
+	var panicErr *PanicError
+	if assert.ErrorAs(t, err, &panicErr) && assert.NotEmpty(t, panicErr.Stack) {
+		assert.True(t, strings.HasPrefix(panicErr.Stack[0].Function, "github.com/antonyho/nice.TestProtectAttempt"),
+			"the stack starts at the panic site: %s", panicErr.Stack[0].Function)
+	}
+
+	n, err = ProtectAttempt(context.Background(), 3, func(context.Context) (int, error) { return 1, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestRetry(t *testing.T) {
+	attempts := 0
+	err := Retry(3, func() {
+		if attempts++; attempts < 3 {
+			panic("flaky")
+		}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = Retry(2, func() {
+		attempts++
+		panic("broken")
+	})
+	assert.EqualError(t, err, "panic: broken")
+	assert.Equal(t, 2, attempts)
+}
+
+func TestSupervise(t *testing.T) {
+	cleanRegistry(t)
+	reporter := make(chanReporter, 4)
+	AddReporter(reporter)
+
+	runs := 0
+	err := Supervise(context.Background(), func(context.Context) {
+		if runs++; runs < 3 {
+			panic("restart me")
+		}
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, runs)
+	assert.Len(t, reporter, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = Supervise(ctx, func(context.Context) { panic("always") })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestProtectDepth(t *testing.T) {
+	var retry, supervise func() error
+	calls := 0
+	retry = func() error {
+		return Retry(1, func() {
+			calls++
+			if err := supervise(); err != nil {
+				panic(err)
+			}
+		})
+	}
+	supervise = func() error {
+		return Supervise(context.Background(), func(context.Context) {
+			if err := retry(); err != nil {
+				panic(err)
+			}
+		})
+	}
+
+	err := retry()
+
+	assert.ErrorIs(t, err, ErrProtectDepth)
+	assert.Contains(t, err.Error(), "32 nested protected calls")
+	assert.Equal(t, MaxProtectDepth/2, calls)
+}
@@ -0,0 +1,62 @@
+package nice
+
+import "sync"
+
+// recent keeps the last dispatched events in a ring buffer.
+var recent struct {
+	sync.Mutex
+	events []PanicEvent
+	// next is the index of the ring to be written.
+	next int
+	full bool
+}
+
+// KeepRecent keeps the last n dispatched events in memory, for Recent,
+// so support tooling and debug endpoints can show what the process recovered from lately.
+// The kept events have their stack captured.
+// A non-positive n keeps none, as by default. Events kept so far are dropped.
+func KeepRecent(n int) {
+	recent.Lock()
+	defer recent.Unlock()
+	recent.events = make([]PanicEvent, max(n, 0))
+	recent.next = 0
+	recent.full = false
+}
+
+// Recent returns up to the n last dispatched events kept by KeepRecent, oldest first.
+// A non-positive n returns all of them.
+// The events are kept without their context, so their Context is context.Background.
+func Recent(n int) []PanicEvent {
+	recent.Lock()
+	defer recent.Unlock()
+	var events []PanicEvent
+	if recent.full {
+		events = append(events, recent.events[recent.next:]...)
+	}
+	events = append(events, recent.events[:recent.next]...)
+	if n > 0 && n < len(events) {
+		events = events[len(events)-n:]
+	}
+	return events
+}
+
+// keepRecent records the event if KeepRecent is enabled.
+// It shall be called from within the deferred function which recovered.
+func keepRecent(event PanicEvent) PanicEvent {
+	recent.Lock()
+	defer recent.Unlock()
+	if len(recent.events) == 0 {
+		return event
+	}
+	event = stacked(event)
+	kept := event
+	// The context would keep the values of its request alive as long as the event is recent.
+	kept.ctx = nil
+	recent.events[recent.next] = kept
+	recent.next++
+	if recent.next == len(recent.events) {
+		recent.next = 0
+		recent.full = true
+	}
+	return event
+}
@@ -0,0 +1,46 @@
+package nice
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecent(t *testing.T) {
+	cleanRegistry(t)
+	KeepRecent(3)
+	t.Cleanup(func() { KeepRecent(0) })
+	Register(reflect.TypeFor[string](), func(any) {})
+
+	assert.Empty(t, Recent(0))
+	for _, message := range []string{"1st", "2nd", "3rd", "4th"} {
+		guarded(message)
+	}
+
+	artefacts := func(events []PanicEvent) (artefacts []any) {
+		for _, e := range events {
+			artefacts = append(artefacts, e.Artefact)
+		}
+		return artefacts
+	}
+	assert.Equal(t, []any{"2nd", "3rd", "4th"}, artefacts(Recent(0)), "The oldest event is dropped.")
+	assert.Equal(t, []any{"3rd", "4th"}, artefacts(Recent(2)))
+	assert.NotEmpty(t, Recent(1)[0].Stack, "Kept events have their stack.")
+
+	type requestKey struct{}
+	func() {
+		defer GuardContext(context.WithValue(context.Background(), requestKey{}, "request"))
+		panic("5th")
+	}()
+	assert.Nil(t, Recent(1)[0].Context().Value(requestKey{}), "Kept events do not hold their context.")
+}
+
+func TestRecentDisabled(t *testing.T) {
+	cleanRegistry(t)
+	Register(reflect.TypeFor[string](), func(any) {})
+
+	guarded("message")
+	assert.Empty(t, Recent(0))
+}
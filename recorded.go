@@ -0,0 +1,65 @@
+package nice
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Recorded is the artefact of a PanicEvent decoded from JSON, e.g. from a production crash report.
+// The original value is lost; only its type name and message are recorded.
+type Recorded struct {
+	TypeName string
+	// Name of the type registered by Describe, if any.
+	Name string
+	Text string
+}
+
+// Error returns the message of the recorded artefact.
+func (r Recorded) Error() string {
+	return r.Text
+}
+
+// Match returns the index of the first pair of the Policy matching the event, as Guard would match its artefact.
+// The artefact of a Recorded event matches the types and errors by type name and message.
+//
+// Recorded types are not known to be errors; one matches the error type
+// unless it is a builtin type, such as string or int.
+func (p Policy) Match(event PanicEvent) (pair int, matched bool) {
+	for i, h := range p.handlers {
+		if h.matchEvent(event) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// matchEvent matches the artefact of the event, recorded or not.
+func (h Handler) matchEvent(event PanicEvent) bool {
+	r, isRecorded := event.Artefact.(Recorded)
+	if !isRecorded {
+		return h.matches(event.Artefact)
+	}
+	for _, t := range h.artefactTypes {
+		// Errors match no type but the error type.
+		if (t.String() == r.TypeName && !t.Implements(typeOfError)) || (t == typeOfError && !isBuiltin(r.TypeName)) {
+			return true
+		}
+	}
+	for _, e := range h.errorTypes {
+		if reflect.TypeOf(e).String() == r.TypeName && e.Error() == r.Text {
+			return true
+		}
+	}
+	for _, m := range h.matchers {
+		if m.Match(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBuiltin tells whether the type name is of a predeclared type, or composed of them only,
+// none of which is an error. Named types are qualified by their package.
+func isBuiltin(typeName string) bool {
+	return !strings.Contains(typeName, ".")
+}
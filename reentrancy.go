@@ -0,0 +1,70 @@
+package nice
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// dispatching holds the artefacts being dispatched by each goroutine, by goroutine ID.
+// The artefacts of a goroutine are only accessed by the goroutine itself.
+var dispatching sync.Map // map[uint64]*[]any
+
+// enterDispatch registers the dispatch of the artefact, and reports whether it re-enters
+// the dispatch of the same artefact on the same goroutine, e.g. from a handler calling a protected helper
+// which panics with the artefact being handled, which would recover, panic and handle it again endlessly.
+// The returned func ends the dispatch.
+func enterDispatch(artefact any) (reentrant bool, exit func()) {
+	if artefact == nil {
+		return false, func() {}
+	}
+	id := goroutineID()
+	var artefacts *[]any
+	if v, ok := dispatching.Load(id); ok {
+		artefacts = v.(*[]any)
+	} else {
+		artefacts = new([]any)
+		dispatching.Store(id, artefacts)
+	}
+	// The artefact may be dispatched by other goroutines, e.g. a sentinel error, which is not re-entrant.
+	reentrant = slices.ContainsFunc(*artefacts, func(dispatched any) bool { return sameArtefact(dispatched, artefact) })
+	*artefacts = append(*artefacts, artefact)
+	return reentrant, func() {
+		*artefacts = (*artefacts)[:len(*artefacts)-1]
+		if len(*artefacts) == 0 {
+			dispatching.Delete(id)
+		}
+	}
+}
+
+// sameArtefact compares the artefacts, which are not the same if they cannot be compared,
+// e.g. a struct holding a slice in an interface field.
+func sameArtefact(a, b any) (same bool) {
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+	return a == b
+}
+
+// goroutineID returns the ID of the calling goroutine, from the header of its stack, e.g. "goroutine 17 [running]:".
+func goroutineID() uint64 {
+	var buf [64]byte
+	header := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	digits, _, _ := bytes.Cut(header, []byte(" "))
+	id, _ := strconv.ParseUint(string(digits), 10, 64)
+	return id
+}
+
+// reentered breaks the loop of the re-entrant dispatch: the event falls through unhandled,
+// into the handler which re-entered, whose panic is subject to OnHandlerPanic.
+func reentered(event PanicEvent) PanicEvent {
+	logError(fmt.Errorf("re-entrant dispatch of %s (%s): a handler dispatched the artefact it is handling, breaking the loop",
+		Stringify(event.Artefact, MaxBytes(256)), event.Type()))
+	event.Metadata = withMetadata(event.Metadata, "reentrant", "true")
+	return event
+}
@@ -0,0 +1,98 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReentrantDispatch(t *testing.T) {
+	cleanRegistry(t)
+	errLoop := errors.New("loop")
+	reporter := &mockReporter{}
+	AddReporter(reporter)
+	calls := 0
+	protected := func() {
+		defer Guard()
+		panic(errLoop)
+	}
+	Register(errLoop, func(any) {
+		calls++
+		protected()
+	})
+
+	assert.PanicsWithValue(t, errLoop, func() {
+		Dispatch(context.Background(), errLoop)
+	})
+	assert.Equal(t, 1, calls, "the loop is broken at the re-entry")
+	assert.Len(t, reporter.events, 1)
+	assert.Equal(t, errLoop, reporter.events[0].HandlerPanic)
+
+	t.Run("Different artefact", func(t *testing.T) {
+		cleanRegistry(t)
+		errInner := errors.New("inner")
+		var handled []any
+		Register(errInner, func(artefact any) { handled = append(handled, artefact) })
+		Register(errLoop, func(artefact any) {
+			handled = append(handled, artefact)
+			func() {
+				defer Guard()
+				panic(errInner)
+			}()
+		})
+
+		event := Dispatch(context.Background(), errLoop)
+
+		assert.True(t, event.Handled)
+		assert.Equal(t, []any{errLoop, errInner}, handled)
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		cleanRegistry(t)
+		release := make(chan struct{})
+		entered := make(chan struct{})
+		Register(errLoop, func(any) {
+			entered <- struct{}{}
+			<-release
+		})
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Dispatch(context.Background(), errLoop)
+		}()
+		<-entered
+
+		reentrant, exit := enterDispatch(errLoop)
+		exit()
+		close(release)
+		wg.Wait()
+
+		assert.False(t, reentrant, "the same artefact dispatched by another goroutine is not re-entrant")
+	})
+}
+
+// unhashable is comparable by its type, but panics on comparison while holding a slice.
+type unhashable struct{ V any }
+
+func TestGuardUnhashableArtefact(t *testing.T) {
+	cleanRegistry(t)
+	var handled any
+	Register(reflect.TypeFor[unhashable](), func(artefact any) { handled = artefact })
+
+	assert.NotPanics(t, func() { guarded(unhashable{V: []int{1}}) })
+	assert.Equal(t, unhashable{V: []int{1}}, handled)
+	assert.False(t, sameArtefact(unhashable{V: []int{1}}, unhashable{V: []int{1}}))
+}
+
+func TestGoroutineID(t *testing.T) {
+	id := goroutineID()
+	assert.NotZero(t, id)
+	other := make(chan uint64)
+	go func() { other <- goroutineID() }()
+	assert.NotEqual(t, id, <-other)
+}
@@ -0,0 +1,112 @@
+package nice
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Kinds of the targets of a Registration.
+const (
+	TargetType    = "type"
+	TargetError   = "error"
+	TargetMatcher = "matcher"
+)
+
+// Registration describes a globally registered handler, as returned by Registered.
+type Registration struct {
+	// Name given by Named or RegisterOnce, or empty.
+	Name string
+	// Priority is the position in which the handler is consulted, from 0.
+	// Handlers of a namespace are consulted ahead of the others for the panics recovered in it.
+	Priority int
+	// Namespace of the handler, or empty.
+	Namespace string
+	// Tenants the handler is restricted to by ForTenant, or none.
+	Tenants []string
+	// Targets of the handler, in the order they are matched.
+	Targets []RegisteredTarget
+	// Tags of the events handled by the handler.
+	Tags map[string]string
+	// Chained tells whether the handler is registered by RegisterChained.
+	Chained bool
+	// Disabled tells whether the handler is disabled by Reload.
+	Disabled bool
+}
+
+// RegisteredTarget describes a target of a Registration.
+type RegisteredTarget struct {
+	// Kind is TargetType, TargetError or TargetMatcher.
+	Kind string
+	// Type name of the type target, or of the error target.
+	Type string
+	// Description, as in the debug log, e.g. `error "EOF" (*errors.errorString)`.
+	Description string
+}
+
+// Registered describes every globally registered handler, in registration order,
+// so operators and debug endpoints can tell what the process would catch right now.
+//
+//	for _, r := range nice.Registered() {
+//		log.Printf("%d %s %v", r.Priority, r.Name, r.Targets)
+//	}
+func Registered() []Registration {
+	return describeRegistrations(loadRegistry())
+}
+
+// describeRegistrations of the snapshot.
+func describeRegistrations(snapshot *registrySnapshot) []Registration {
+	described := make([]Registration, len(snapshot.registrations))
+	for i, r := range snapshot.registrations {
+		described[i] = Registration{
+			Name:      r.name,
+			Priority:  i,
+			Namespace: r.namespace,
+			Tenants:   slices.Clone(r.tenants),
+			Targets:   r.handler.describeTargets(),
+			Tags:      maps.Clone(mergeTags(r.handler.tags, r.tags)),
+			Chained:   r.chained != nil,
+			Disabled:  snapshot.config.settings(r.name).Disabled,
+		}
+	}
+	return described
+}
+
+// describeTargets of the Handler in the order they are matched.
+func (h Handler) describeTargets() []RegisteredTarget {
+	targets := make([]RegisteredTarget, 0, len(h.artefactTypes)+len(h.errorTypes)+len(h.matchers))
+	for _, t := range h.artefactTypes {
+		targets = append(targets, RegisteredTarget{Kind: TargetType, Type: t.String(), Description: describeTarget(t)})
+	}
+	for _, err := range h.errorTypes {
+		targets = append(targets, RegisteredTarget{Kind: TargetError, Type: fmt.Sprintf("%T", err), Description: describeTarget(err)})
+	}
+	for _, m := range h.matchers {
+		targets = append(targets, RegisteredTarget{Kind: TargetMatcher, Description: describeTarget(m)})
+	}
+	return targets
+}
+
+// HandlerStats counts the matches of a registered handler, as returned by Stats.
+type HandlerStats struct {
+	Registration
+	// Matched is the number of artefacts the handler matched since it was registered,
+	// whether or not it was called for them, e.g. when rate limited.
+	Matched uint64
+}
+
+// Stats counts the matches of every globally registered handler since it was registered, in registration order,
+// so dead registrations and unexpectedly hot panic paths are both easy to spot.
+// See nicehttp.PublishStats for expvar.
+func Stats() []HandlerStats {
+	snapshot := loadRegistry()
+	registered := describeRegistrations(snapshot)
+	stats := make([]HandlerStats, len(registered))
+	for i, r := range snapshot.registrations {
+		stats[i].Registration = registered[i]
+		if r.matched != nil {
+			stats[i].Matched = r.matched.Load()
+		}
+	}
+	return stats
+}
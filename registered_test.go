@@ -0,0 +1,65 @@
+package nice
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistered(t *testing.T) {
+	cleanRegistry(t)
+	assert.Empty(t, Registered())
+
+	Register(io.EOF, func(any) {}, Named("eof"), Tags(map[string]string{"team": "io"}))
+	Register(Tackle(reflect.TypeFor[string](), Is(io.ErrUnexpectedEOF)), func(any) {})
+	RegisterChained(reflect.TypeFor[error](), func(PanicEvent) HandleResult { return Continue })
+	Namespace("billing").Register(io.EOF, func(any) {})
+	Reload(Config{Handlers: map[string]HandlerConfig{"eof": {Disabled: true}}})
+	t.Cleanup(func() { Reload(Config{}) })
+
+	assert.Equal(t, []Registration{
+		{
+			Name:     "eof",
+			Priority: 0,
+			Targets:  []RegisteredTarget{{Kind: TargetError, Type: "*errors.errorString", Description: `error "EOF" (*errors.errorString)`}},
+			Tags:     map[string]string{"team": "io"},
+			Disabled: true,
+		},
+		{
+			Priority: 1,
+			Targets: []RegisteredTarget{
+				{Kind: TargetType, Type: "string", Description: "type string"},
+				{Kind: TargetMatcher, Description: `error wrapping "unexpected EOF" (*errors.errorString)`},
+			},
+		},
+		{
+			Priority: 2,
+			Targets:  []RegisteredTarget{{Kind: TargetType, Type: "error", Description: "type error"}},
+			Chained:  true,
+		},
+		{
+			Priority:  3,
+			Namespace: "billing",
+			Targets:   []RegisteredTarget{{Kind: TargetError, Type: "*errors.errorString", Description: `error "EOF" (*errors.errorString)`}},
+		},
+	}, Registered())
+}
+
+func TestStats(t *testing.T) {
+	cleanRegistry(t)
+	Register(io.EOF, func(any) {}, Named("eof"))
+	Register(reflect.TypeFor[string](), func(any) {})
+	Register(reflect.TypeFor[int](), func(any) {})
+
+	guarded(io.EOF)
+	guarded("x")
+	guarded("y")
+
+	stats := Stats()
+	assert.Len(t, stats, 3)
+	assert.Equal(t, "eof", stats[0].Name)
+	assert.Equal(t, []uint64{1, 2, 0}, []uint64{stats[0].Matched, stats[1].Matched, stats[2].Matched},
+		"a dead registration counts no match")
+}
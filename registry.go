@@ -0,0 +1,476 @@
+package nice
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// registration pairs the targets of a Handler with its handle func.
+type registration struct {
+	registerOptions
+	id      uint64
+	handler Handler
+	handle  func(event PanicEvent)
+	// chained is the handle func of RegisterChained, deciding whether the next handlers run.
+	chained func(event PanicEvent) HandleResult
+	// namespace of the registration, see Namespace.
+	namespace string
+	// matched counts the artefacts matched, once registered globally. See Stats.
+	matched *atomic.Uint64
+}
+
+// reporterEntry is a globally added Reporter.
+type reporterEntry struct {
+	registerOptions
+	reporter Reporter
+}
+
+// RegisterOption configures a registration by Register or AddReporter.
+type RegisterOption func(*registerOptions)
+
+type registerOptions struct {
+	name       string
+	needsStack bool
+	tags       map[string]string
+	deadline   time.Duration
+	// tenants of the registration, see ForTenant.
+	tenants []string
+}
+
+// Named gives the registration a name, by which it is configured with Reload.
+func Named(name string) RegisterOption {
+	return func(o *registerOptions) { o.name = name }
+}
+
+// NeedsStack declares that the handler or reporter reads the stack of the event.
+// Capturing the stack is expensive, so it is captured only if the matched handler
+// or any reporter needs it, or if no handler matched.
+func NeedsStack() RegisterOption {
+	return func(o *registerOptions) { o.needsStack = true }
+}
+
+// Deadline runs the handler under a context which expires after d, as the context of the event.
+// Once the handler overruns it, the overrun is recorded as "overrun" in the metadata of the event,
+// and the dispatch proceeds to the next matching handler, or falls through,
+// without waiting for the abandoned handler any longer.
+// Handlers with a deadline run in their own goroutine.
+//
+//	nice.RegisterEvent(reflect.TypeFor[error](), nice.WebhookHandler(url, nil), nice.Deadline(2*time.Second))
+func Deadline(d time.Duration) RegisterOption {
+	return func(o *registerOptions) { o.deadline = d }
+}
+
+// Tags attaches static tags to every event handled by the registration,
+// e.g. the team, subsystem or tier, for routing, metrics labels and grouping of reports.
+// It is also accepted by Tackle as a target, tagging the events of the Handler when registered.
+//
+//	nice.Register(ErrDeclined, alert, nice.Tags(map[string]string{"team": "payments"}))
+func Tags(tags map[string]string) RegisterOption {
+	return func(o *registerOptions) {
+		o.tags = mergeTags(o.tags, tags)
+	}
+}
+
+// mergeTags returns the tags of base overridden by tags, without modifying either.
+func mergeTags(base, tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return base
+	}
+	merged := make(map[string]string, len(base)+len(tags))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return merged
+}
+
+func newRegisterOptions(opts []RegisterOption) registerOptions {
+	var o registerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// registry holds the globally registered handlers and reporters.
+// Recovery points read a snapshot without locking,
+// while writers replace it under the mutex, copying on write.
+var registry struct {
+	sync.Mutex
+	snapshot atomic.Pointer[registrySnapshot]
+	lastID   uint64
+}
+
+// registrySnapshot is never modified once stored.
+type registrySnapshot struct {
+	registrations []registration
+	reporters     []reporterEntry
+	config        runtimeConfig
+}
+
+// loadRegistry returns the current snapshot of the registry.
+func loadRegistry() *registrySnapshot {
+	if s := registry.snapshot.Load(); s != nil {
+		return s
+	}
+	return &registrySnapshot{}
+}
+
+// updateRegistry stores the snapshot updated by fn.
+// fn gets a shallow copy of the current snapshot, so it shall replace slices, not modify them.
+// It shall be called with the registry locked.
+func updateRegistry(fn func(s *registrySnapshot)) {
+	s := *loadRegistry()
+	fn(&s)
+	registry.snapshot.Store(&s)
+}
+
+// Register the handle func for the target globally.
+// The target is anything accepted by Tackle, or a Handler returned by Tackle
+// for registering multiple targets at once.
+// Registered handlers are consulted by Guard and the other recovery points
+// of this package in registration order. The first matched one handles the artefact.
+func Register(target any, handle func(artefact any), opts ...RegisterOption) {
+	RegisterEvent(target, handleArtefact(handle), opts...)
+}
+
+// RegisterEvent registers the handle func for the target globally, as Register,
+// with the handle func receiving the whole event including its stack and metadata.
+func RegisterEvent(target any, handle func(event PanicEvent), opts ...RegisterOption) {
+	register(registration{
+		registerOptions: newRegisterOptions(opts),
+		handler:         toHandler(target),
+		handle:          handle,
+	})
+}
+
+// HandleResult of a handle func registered by RegisterChained,
+// deciding whether the next matching handlers in the chain run too.
+type HandleResult int
+
+const (
+	// Stop the chain at the handler, as for the handlers registered by Register.
+	Stop HandleResult = iota
+	// Continue the chain with the next matching handler.
+	Continue
+)
+
+// RegisterChained registers the handle func for the target globally, as RegisterEvent,
+// with the handle func deciding whether the next matching registered handlers run too.
+// Returning Continue from every handler broadcasts the event, while returning Stop keeps first-match.
+// The event returned and reported is of the first matched handler.
+//
+//	nice.RegisterChained(reflect.TypeFor[error](), func(event nice.PanicEvent) nice.HandleResult {
+//		audit(event)
+//		return nice.Continue
+//	})
+func RegisterChained(target any, handle func(event PanicEvent) HandleResult, opts ...RegisterOption) {
+	register(registration{
+		registerOptions: newRegisterOptions(opts),
+		handler:         toHandler(target),
+		chained:         handle,
+	})
+}
+
+// handleArtefact adapts the handle func of Handler.With to the events.
+// It receives the matched artefact as from With, e.g. the Member matched of a joined error.
+func handleArtefact(handle func(artefact any)) func(event PanicEvent) {
+	return func(event PanicEvent) {
+		if event.resolved != nil {
+			handle(event.resolved)
+			return
+		}
+		handle(event.Artefact)
+	}
+}
+
+// RegisterOnce registers the handle func for the target under the name, as Register,
+// if nothing has been registered under the same name yet.
+// It reports whether the handle func has been registered.
+// Packages can install their default handlers from init paths which may run multiple times,
+// e.g. in tests and plugins, without duplicating them.
+func RegisterOnce(name string, target any, handle func(artefact any), opts ...RegisterOption) bool {
+	options := newRegisterOptions(opts)
+	options.name = name
+
+	registry.Lock()
+	defer registry.Unlock()
+	for _, r := range loadRegistry().registrations {
+		if r.name == name {
+			return false
+		}
+	}
+	registry.lastID++
+	r := registration{
+		registerOptions: options,
+		id:              registry.lastID,
+		matched:         new(atomic.Uint64),
+		handler:         toHandler(target),
+		handle:          handleArtefact(handle),
+	}
+	updateRegistry(func(s *registrySnapshot) {
+		s.registrations = append(slices.Clip(s.registrations), r)
+	})
+	return true
+}
+
+// register returns the ID of the registration for deregister.
+func register(r registration) uint64 {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.lastID++
+	r.id = registry.lastID
+	r.matched = new(atomic.Uint64)
+	updateRegistry(func(s *registrySnapshot) {
+		s.registrations = append(slices.Clip(s.registrations), r)
+	})
+	return r.id
+}
+
+// deregister removes the registrations by ID.
+// Dispatch in progress keeps its snapshot.
+func deregister(ids ...uint64) {
+	registry.Lock()
+	defer registry.Unlock()
+	updateRegistry(func(s *registrySnapshot) {
+		s.registrations = slices.DeleteFunc(slices.Clone(s.registrations), func(r registration) bool {
+			return slices.Contains(ids, r.id)
+		})
+	})
+}
+
+// toHandler takes a Handler as it is, or tackles the target.
+func toHandler(target any) Handler {
+	if handler, matched := target.(Handler); matched {
+		return handler
+	}
+	return Tackle(target)
+}
+
+// Guard recovers panic and dispatches the artefact to the globally registered handlers.
+// It shall be deferred directly, as Handler.With.
+// The panic falls through if no registered handler matches.
+//
+//	go func() {
+//		defer nice.Guard()
+//		work()
+//	}()
+func Guard() {
+	if RecoveryDisabled {
+		return
+	}
+	if artefact := recover(); artefact != nil {
+		Fallthrough(recovered(context.Background(), newEvent(artefact), "Guard"))
+	}
+}
+
+// dispatchRegistered dispatches the event to the first matched registered handler,
+// then to every reporter.
+// Disabled and rate limited registrations are skipped as configured by Reload.
+func dispatchRegistered(event PanicEvent) PanicEvent {
+	return dispatchWith(event, nil)
+}
+
+// dispatchWith consults the local registrations ahead of the registered ones.
+func dispatchWith(event PanicEvent, local []registration) PanicEvent {
+	reentrant, exit := enterDispatch(event.Artefact)
+	defer exit()
+	if reentrant {
+		return reentered(event)
+	}
+	typeTags := describedTags(event.Artefact)
+	event.Tags = mergeTags(typeTags, event.Tags)
+	snapshot := loadRegistry()
+	registrations := snapshot.registrations
+	reporters := snapshot.reporters
+	config := snapshot.config
+	namespace := namespaceFromContext(event.Context())
+	// scopeTags tag the event, as soon as the handlers see it, by the scope of its context.
+	var scopeTags map[string]string
+	if namespace != "" {
+		registrations = inNamespaceFirst(namespace, registrations)
+		scopeTags = map[string]string{"namespace": namespace}
+	}
+	tenant := TenantFromContext(event.Context())
+	if tenant != "" {
+		scopeTags = mergeTags(scopeTags, map[string]string{"tenant": tenant})
+	}
+	event.Tags = mergeTags(event.Tags, scopeTags)
+	if len(local) > 0 {
+		registrations = slices.Concat(local, registrations)
+	}
+	if tenant != "" || slices.ContainsFunc(registrations, func(r registration) bool { return len(r.tenants) > 0 }) {
+		registrations = forTenant(tenant, registrations)
+	}
+
+	logger := debugLogger.Load()
+	debugRecovered(logger, "registry", event.Artefact)
+	fanOut := fanningOut(event.Artefact)
+	artefact := normalize(event.Artefact)
+	for i, r := range registrations {
+		settings := config.settings(r.name)
+		name := r.name
+		if logger != nil && name == "" {
+			name = fmt.Sprintf("registration #%d", i)
+		}
+		if r.namespace != "" && r.namespace != namespace {
+			continue
+		}
+		if settings.Disabled {
+			if logger != nil {
+				logger.LogAttrs(context.Background(), slog.LevelDebug, "nice: handler disabled", slog.String("handler", name))
+			}
+			continue
+		}
+		resolved, matched := r.handler.resolveNormalized(artefact, debugTracer(logger, name))
+		if !matched {
+			continue
+		}
+		if r.matched != nil {
+			r.matched.Add(1)
+		}
+		matchedEvent := event
+		matchedEvent.Member = nil
+		matchedEvent.resolved = resolved
+		if member, isMember := resolved.(Member); isMember {
+			matchedEvent.Member = member.Err
+		}
+		matchedEvent.Handled = true
+		matchedEvent.Severity = settings.Severity
+		matchedEvent.Tags = mergeTags(mergeTags(typeTags, mergeTags(r.handler.tags, r.tags)), scopeTags)
+		debugOutcome(logger, "registry", name, true)
+		// The first matched registration decides the event, as reported.
+		if !event.Handled && (stormsEnabled() || sampleRate() >= 2) {
+			// Fingerprinting by the stack, which the reporters receive too.
+			matchedEvent = stacked(matchedEvent)
+			fingerprint := Fingerprint(matchedEvent)
+			if storming(matchedEvent, fingerprint) {
+				return matchedEvent
+			}
+			matchedEvent = sampled(matchedEvent, fingerprint)
+		}
+		if r.needsStack {
+			matchedEvent = stacked(matchedEvent)
+		}
+		handle, result := r.handle, Stop
+		if r.chained != nil {
+			handle = func(event PanicEvent) { result = r.chained(event) }
+		}
+		allowed := config.allow(r.name)
+		if allowed && r.deadline > 0 {
+			start := time.Now()
+			var overrun bool
+			matchedEvent.HandlerPanic, overrun = runHandleWithin(r.deadline, handle, matchedEvent)
+			observeHandler("handler", registrationName(r, i), start, matchedEvent.HandlerPanic, overrun)
+			if overrun {
+				// Abandon the handler, and proceed to the next matching one or fall through.
+				event.Metadata = withMetadata(event.Metadata, "overrun", registrationName(r, i))
+				logError(fmt.Errorf("handler %s overran its deadline of %v handling %s", registrationName(r, i), r.deadline, event.Type()))
+				continue
+			}
+		} else if allowed {
+			start := time.Now()
+			matchedEvent.HandlerPanic = runHandle(handle, matchedEvent)
+			observeHandler("handler", registrationName(r, i), start, matchedEvent.HandlerPanic, false)
+		} else {
+			matchedEvent.Metadata = withMetadata(matchedEvent.Metadata, "rate_limited", r.name)
+		}
+		if !event.Handled {
+			event = matchedEvent
+		} else if event.HandlerPanic == nil {
+			event.HandlerPanic = matchedEvent.HandlerPanic
+		}
+		if !fanOut && result != Continue {
+			break
+		}
+	}
+	if !event.Handled {
+		debugOutcome(logger, "registry", "", false)
+		event = stacked(event)
+	}
+
+	event = report(event, reporters, config)
+	if event.HandlerPanic != nil {
+		handlerPanicked(event.Artefact, event.HandlerPanic)
+	}
+	return event
+}
+
+// registrationName names the registration in diagnostics.
+func registrationName(r registration, i int) string {
+	if r.name != "" {
+		return r.name
+	}
+	return fmt.Sprintf("registration #%d", i)
+}
+
+// reporterName names the reporter in diagnostics.
+func reporterName(r reporterEntry, i int) string {
+	if r.name != "" {
+		return r.name
+	}
+	return fmt.Sprintf("reporter #%d", i)
+}
+
+// runHandleWithin runs the handle func in its own goroutine under the deadline,
+// returning its panic, or whether it overran the deadline.
+func runHandleWithin(deadline time.Duration, handle func(event PanicEvent), event PanicEvent) (handlerPanic any, overrun bool) {
+	ctx, cancel := context.WithTimeout(event.Context(), deadline)
+	defer cancel()
+	event.ctx = ctx
+	done := make(chan any, 1)
+	go func() {
+		done <- runHandle(handle, event)
+	}()
+	// Waiting on the deadline rather than the context, which may be cancelled by its parent.
+	timer := time.NewTimer(deadline)
+	defer timer.Stop()
+	select {
+	case handlerPanic = <-done:
+		return handlerPanic, false
+	case <-timer.C:
+		return nil, true
+	}
+}
+
+// report the event to the reporters which are enabled and not rate limited,
+// and keep it for Recent. A reporter's panic is attached to the event, unless a handler panicked already.
+func report(event PanicEvent, reporters []reporterEntry, config runtimeConfig) PanicEvent {
+	event = keepRecent(event)
+	for i, r := range reporters {
+		if _, budget := r.reporter.(*Budget); event.sampledOut && !budget {
+			// A Budget counts every panic, sampled out or not.
+			continue
+		}
+		if !config.settings(r.name).Disabled && config.allow(r.name) {
+			if r.needsStack {
+				event = stacked(event)
+			}
+			start := time.Now()
+			reporterPanic := runHandle(r.reporter.Report, event)
+			observeHandler("reporter", reporterName(r, i), start, reporterPanic, false)
+			if reporterPanic != nil && event.HandlerPanic == nil {
+				// The next reporters still receive the event, and the policy applies after them.
+				event.HandlerPanic = reporterPanic
+			}
+		}
+	}
+	return event
+}
+
+// withMetadata sets the key on a copy of the metadata.
+func withMetadata(metadata map[string]string, key, value string) map[string]string {
+	copied := make(map[string]string, len(metadata)+1)
+	for k, v := range metadata {
+		copied[k] = v
+	}
+	copied[key] = value
+	return copied
+}
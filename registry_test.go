@@ -0,0 +1,232 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// cleanRegistry empties the global registry for the test
+// and restores it afterwards.
+func cleanRegistry(t *testing.T) {
+	t.Helper()
+	saved := registry.snapshot.Swap(&registrySnapshot{})
+
+	t.Cleanup(func() {
+		registry.snapshot.Store(saved)
+	})
+}
+
+// reported isolates the registry as cleanRegistry, returning the reporter of the events dispatched.
+func reported(t *testing.T) *mockReporter {
+	t.Helper()
+	cleanRegistry(t)
+	reporter := &mockReporter{}
+	AddReporter(reporter)
+	return reporter
+}
+
+type mockReporter struct {
+	events  []PanicEvent
+	flushed bool
+}
+
+func (r *mockReporter) Report(event PanicEvent) {
+	r.events = append(r.events, event)
+}
+
+func (r *mockReporter) Flush(context.Context) error {
+	r.flushed = true
+	return nil
+}
+
+func TestGuard(t *testing.T) {
+	t.Run("handle registered target", func(t *testing.T) {
+		cleanRegistry(t)
+		var handled any
+		Register(reflect.TypeFor[string](), func(artefact any) { handled = artefact })
+
+		func() {
+			defer Guard()
+			panic("registered")
+		}()
+
+		assert.Equal(t, "registered", handled)
+	})
+
+	t.Run("first registered handler wins", func(t *testing.T) {
+		cleanRegistry(t)
+		mockErr := errors.New("mock error")
+		var executed []string
+		Register(mockErr, func(any) { executed = append(executed, "1st") })
+		Register(Tackle(), func(any) { executed = append(executed, "2nd") })
+
+		func() {
+			defer Guard()
+			panic(mockErr)
+		}()
+
+		assert.Equal(t, []string{"1st"}, executed)
+	})
+
+	t.Run("report handled and unhandled events", func(t *testing.T) {
+		cleanRegistry(t)
+		reporter := &mockReporter{}
+		AddReporter(reporter)
+		Register(reflect.TypeFor[string](), func(any) {})
+
+		func() {
+			defer Guard()
+			panic("handled")
+		}()
+		func() {
+			defer func() {
+				if artefact := recover(); artefact == nil {
+					t.Error("Unhandled panic did not fallthrough.")
+				}
+			}()
+			defer Guard()
+			panic(7)
+		}()
+
+		if assert.Len(t, reporter.events, 2) {
+			assert.True(t, reporter.events[0].Handled)
+			assert.Equal(t, "handled", reporter.events[0].Artefact)
+			assert.False(t, reporter.events[1].Handled)
+			assert.Equal(t, 7, reporter.events[1].Artefact)
+		}
+	})
+}
+
+func TestNeedsStack(t *testing.T) {
+	t.Run("no stack unless needed", func(t *testing.T) {
+		cleanRegistry(t)
+		reporter := &mockReporter{}
+		AddReporter(reporter)
+		var event PanicEvent
+		RegisterEvent(reflect.TypeFor[string](), func(e PanicEvent) { event = e })
+
+		guarded("handled")
+
+		assert.True(t, event.Handled)
+		assert.Nil(t, event.Stack)
+		assert.Nil(t, reporter.events[0].Stack)
+	})
+
+	t.Run("matched handler needs stack", func(t *testing.T) {
+		cleanRegistry(t)
+		var event PanicEvent
+		RegisterEvent(reflect.TypeFor[string](), func(e PanicEvent) { event = e }, NeedsStack())
+
+		guarded("handled")
+
+		if assert.NotEmpty(t, event.Stack) {
+			assert.Equal(t, "github.com/antonyho/nice.guarded", event.Stack[0].Function)
+		}
+	})
+
+	t.Run("reporter needs stack", func(t *testing.T) {
+		cleanRegistry(t)
+		reporter := &mockReporter{}
+		AddReporter(reporter, NeedsStack())
+		Register(reflect.TypeFor[string](), func(any) {})
+
+		guarded("handled")
+
+		assert.NotEmpty(t, reporter.events[0].Stack)
+	})
+
+	t.Run("unhandled event has stack", func(t *testing.T) {
+		cleanRegistry(t)
+		reporter := &mockReporter{}
+		AddReporter(reporter)
+
+		assert.Panics(t, func() { guarded("unhandled") })
+		assert.NotEmpty(t, reporter.events[0].Stack)
+	})
+
+	t.Run("parsed event keeps its stack", func(t *testing.T) {
+		cleanRegistry(t)
+		var event PanicEvent
+		RegisterEvent(Type[*CrashError](), func(e PanicEvent) { event = e }, NeedsStack())
+		parsed, err := ParseCrash(strings.NewReader("panic: boom\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dispatchRegistered(parsed)
+
+		assert.True(t, event.Handled)
+		assert.Nil(t, event.Stack, "the stack of the dispatching goroutine is not the one of the crash")
+	})
+}
+
+func TestRegisterType(t *testing.T) {
+	cleanRegistry(t)
+	var op string
+	RegisterType(func(err *fs.PathError) { op = err.Op })
+
+	guarded(&fs.PathError{Op: "open"})
+
+	assert.Equal(t, "open", op)
+	assert.Panics(t, func() { guarded("other") })
+}
+
+func TestRegisterOnce(t *testing.T) {
+	cleanRegistry(t)
+	var executed []string
+	install := func() bool {
+		return RegisterOnce("defaults", reflect.TypeFor[string](), func(any) {
+			executed = append(executed, "defaults")
+		})
+	}
+
+	assert.True(t, install())
+	assert.False(t, install(), "Registering again under the same name is no-op.")
+	func() {
+		defer Guard()
+		panic("once")
+	}()
+
+	assert.Equal(t, []string{"defaults"}, executed)
+}
+
+func TestFlush(t *testing.T) {
+	cleanRegistry(t)
+	reporter := &mockReporter{}
+	AddReporter(reporter)
+
+	assert.NoError(t, Flush(context.Background()))
+	assert.True(t, reporter.flushed)
+}
+
+func TestRegistryConcurrency(t *testing.T) {
+	cleanRegistry(t)
+	Register(reflect.TypeFor[string](), func(any) {})
+
+	var wg sync.WaitGroup
+	wg.Add(8)
+	for range 4 {
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				id := register(registration{handler: Tackle(reflect.TypeFor[int]()), handle: func(PanicEvent) {}})
+				deregister(id)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				assert.NotPanics(t, func() { guarded("registered") })
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, loadRegistry().registrations, 1)
+}
@@ -0,0 +1,53 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// Reporter receives every event dispatched to the global registry,
+// whether or not it has been handled.
+type Reporter interface {
+	Report(event PanicEvent)
+}
+
+// ReporterFunc adapts an event handle func, such as the built-in handlers, to Reporter.
+type ReporterFunc func(event PanicEvent)
+
+// Report calls f(event).
+func (f ReporterFunc) Report(event PanicEvent) {
+	f(event)
+}
+
+// Flusher is implemented by reporters which buffer or send events asynchronously.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// AddReporter registers the reporter globally.
+func AddReporter(r Reporter, opts ...RegisterOption) {
+	entry := reporterEntry{
+		registerOptions: newRegisterOptions(opts),
+		reporter:        r,
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	updateRegistry(func(s *registrySnapshot) {
+		s.reporters = append(slices.Clip(s.reporters), entry)
+	})
+}
+
+// Flush every registered reporter which implements Flusher.
+// It shall be called before the process exits.
+func Flush(ctx context.Context) error {
+	reporters := loadRegistry().reporters
+
+	var errs []error
+	for _, r := range reporters {
+		if f, matched := r.reporter.(Flusher); matched {
+			errs = append(errs, f.Flush(ctx))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,77 @@
+package nice
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// MetricSampledOut counts the handled events which were not passed to the handlers and reporters, see Sample.
+const MetricSampledOut = "nice_sampled_out_total"
+
+// maxSampledKeys bounds the fingerprints counted by Sample, which start over once exceeded.
+const maxSampledKeys = 4096
+
+// samples counts the events per fingerprint.
+// The rate is atomic, as mode, so dispatches load it without locking.
+var samples struct {
+	sync.Mutex
+	rate   atomic.Int64
+	counts map[string]int
+}
+
+// Sample keeps the costs of noisy recoverable panics bounded in production:
+// of the same panic handled by the registered handlers, only the first of every rate events
+// is logged and reported, with the rate recorded as "sample_rate" in the metadata.
+// The other events are still handled by every handler, but are flagged by PanicEvent.SampledOut,
+// so the logging handlers and the reporters skip them; they are counted as MetricSampledOut
+// to the sink of SetMetrics, and by MetricsHandler, so the totals stay exact.
+// Panics are the same if they have the same Fingerprint.
+// Nothing is sampled out in Development mode.
+// A rate below 2 disables the sampling, as by default.
+//
+//	nice.Sample(100)
+func Sample(rate int) {
+	samples.Lock()
+	defer samples.Unlock()
+	samples.rate.Store(int64(rate))
+	samples.counts = nil
+}
+
+// sampled counts the handled event of the fingerprint, and flags it if sampled out.
+// The event sampled in is returned with its sample rate.
+func sampled(event PanicEvent, key string) PanicEvent {
+	rate := sampleRate()
+	if rate < 2 {
+		return event
+	}
+	if n := sampleCount(key, rate); n == 0 {
+		event.Metadata = withMetadata(event.Metadata, "sample_rate", strconv.Itoa(rate))
+		return event
+	}
+	if o := observed.Load(); o.metrics != nil {
+		o.metrics.Count(MetricSampledOut, map[string]string{"type": event.TypeName()})
+	}
+	event.sampledOut = true
+	return event
+}
+
+// sampleRate returns the rate set by Sample, or 0 as nothing is sampled out in Development mode.
+func sampleRate() int {
+	if Mode(mode.Load()) == Development {
+		return 0
+	}
+	return int(samples.rate.Load())
+}
+
+// sampleCount counts the event of the fingerprint, returning its count before, modulo the rate.
+func sampleCount(key string, rate int) int {
+	samples.Lock()
+	defer samples.Unlock()
+	if samples.counts == nil || len(samples.counts) >= maxSampledKeys {
+		samples.counts = make(map[string]int)
+	}
+	n := samples.counts[key]
+	samples.counts[key] = (n + 1) % rate
+	return n
+}
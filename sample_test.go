@@ -0,0 +1,67 @@
+package nice
+
+import (
+	"bytes"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSample(t *testing.T) {
+	cleanRegistry(t)
+	Sample(3)
+	t.Cleanup(func() { Sample(0) })
+	sink := &mockSink{}
+	SetMetrics(sink)
+	t.Cleanup(func() { SetMetrics(nil) })
+	reporter := &mockReporter{}
+	AddReporter(reporter)
+	budget := NewBudget(100, time.Minute)
+	AddReporter(budget)
+	handled := 0
+	RegisterChained(reflect.TypeFor[string](), func(PanicEvent) HandleResult {
+		handled++
+		return Continue
+	})
+	var logged bytes.Buffer
+	RegisterEvent(reflect.TypeFor[string](), LogHandler(slog.New(slog.NewTextHandler(&logged, nil))))
+
+	for range 7 {
+		guarded("noisy")
+	}
+	guarded("other")
+
+	assert.Equal(t, 8, handled, "every event is handled")
+	assert.Equal(t, 4, strings.Count(logged.String(), "panic tackled"), "1 in 3 of the same panic is logged, and the other panic")
+	assert.Len(t, reporter.events, 4)
+	assert.Equal(t, "3", reporter.events[0].Metadata["sample_rate"])
+	assert.Equal(t, 8, budget.Spent(), "a budget counts the sampled out events")
+	assert.Len(t, sink.counts, 4, "the sampled out events are still counted")
+	for i, name := range sink.counts {
+		assert.Equal(t, MetricSampledOut, name)
+		assert.Equal(t, "string", sink.labels[i]["type"])
+	}
+
+	SetMode(Development)
+	t.Cleanup(func() { SetMode(Production) })
+	assert.Panics(t, func() { guarded("noisy") })
+	assert.Panics(t, func() { guarded("noisy") })
+	assert.Equal(t, 10, handled)
+	assert.Equal(t, 6, strings.Count(logged.String(), "panic tackled"), "Development mode samples nothing out")
+}
+
+func TestSampleDisabled(t *testing.T) {
+	cleanRegistry(t)
+	handled := 0
+	Register(reflect.TypeFor[string](), func(any) { handled++ })
+
+	for range 5 {
+		guarded("noisy")
+	}
+
+	assert.Equal(t, 5, handled)
+}
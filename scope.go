@@ -0,0 +1,49 @@
+package nice
+
+import (
+	"sync"
+)
+
+// Scope collects cleanup funcs, e.g. rollbacks, to be run in reverse order
+// when the protected block ends, as a structured alternative to scattering defers.
+// The zero value is ready to use.
+type Scope struct {
+	mu       sync.Mutex
+	cleanups []func()
+}
+
+// Defer pushes the cleanup func onto the scope.
+func (s *Scope) Defer(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cleanups = append(s.cleanups, fn)
+}
+
+// Close runs the pushed cleanup funcs in reverse order and empties the scope.
+// They behave as deferred calls: a panicking cleanup does not stop the rest from running.
+func (s *Scope) Close() {
+	s.mu.Lock()
+	cleanups := s.cleanups
+	s.cleanups = nil
+	s.mu.Unlock()
+
+	for _, fn := range cleanups {
+		defer fn()
+	}
+}
+
+// Unwind returns a Handler which closes the scope.
+// On panic, the cleanups run before the artefact is matched and handled,
+// so the handlers see the state after rollback.
+// Otherwise they run normally after With.
+//
+//	var scope nice.Scope
+//	defer nice.Tackle(ErrTransfer).Unwind(&scope).With(reportFailedTransfer)
+//
+//	debit(from, amount)
+//	scope.Defer(func() { credit(from, amount) })
+//	credit(to, amount)
+func (h Handler) Unwind(s *Scope) Handler {
+	h.before = append(h.before[:len(h.before):len(h.before)], s.Close)
+	return h.Finally(s.Close)
+}
@@ -0,0 +1,66 @@
+package nice_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/antonyho/nice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScope(t *testing.T) {
+	t.Run("unwind before handling", func(t *testing.T) {
+		var executed []string
+		func() {
+			var scope nice.Scope
+			defer nice.Tackle().Unwind(&scope).With(func(any) { executed = append(executed, "handle") })
+
+			scope.Defer(func() { executed = append(executed, "1st cleanup") })
+			scope.Defer(func() { executed = append(executed, "2nd cleanup") })
+			panic(errors.New("mock error"))
+		}()
+
+		assert.Equal(t, []string{"2nd cleanup", "1st cleanup", "handle"}, executed)
+	})
+
+	t.Run("close on success", func(t *testing.T) {
+		var executed []string
+		func() {
+			var scope nice.Scope
+			defer nice.Tackle().Unwind(&scope).With(func(any) { executed = append(executed, "handle") })
+
+			scope.Defer(func() { executed = append(executed, "1st cleanup") })
+			scope.Defer(func() { executed = append(executed, "2nd cleanup") })
+		}()
+
+		assert.Equal(t, []string{"2nd cleanup", "1st cleanup"}, executed)
+	})
+
+	t.Run("unwind on fallthrough", func(t *testing.T) {
+		var executed []string
+		func() {
+			defer func() {
+				if artefact := recover(); artefact == nil {
+					t.Error("Unhandled panic did not fallthrough.")
+				}
+			}()
+			var scope nice.Scope
+			defer nice.Tackle().Unwind(&scope).With(func(any) { executed = append(executed, "handle") })
+
+			scope.Defer(func() { executed = append(executed, "cleanup") })
+			panic(7)
+		}()
+
+		assert.Equal(t, []string{"cleanup"}, executed, "Cleanups run once.")
+	})
+
+	t.Run("panicking cleanup", func(t *testing.T) {
+		var executed []string
+		var scope nice.Scope
+		scope.Defer(func() { executed = append(executed, "1st cleanup") })
+		scope.Defer(func() { panic("cleanup failed") })
+
+		assert.PanicsWithValue(t, "cleanup failed", scope.Close)
+		assert.Equal(t, []string{"1st cleanup"}, executed)
+	})
+}
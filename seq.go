@@ -0,0 +1,92 @@
+package nice
+
+import "iter"
+
+// SafeSeq wraps the iterator so a panic of the iterator, or of the body of a range loop over it,
+// is tackled by the handler with the current element, i.e. the last one yielded.
+// The handle func is given the artefact as With gives it, and the element.
+// A panic of the iterator ends the loop as if the iterator returned.
+// A panic of the loop body is raised again once handled, as range-over-func requires,
+// so it shall still be recovered around the loop; the handle func sees the element which caused it.
+// A panic not matched by the handler falls through.
+//
+//	for row := range nice.SafeSeq(rows.All(), nice.Tackle(), func(artefact any, row Row) {
+//		log.Printf("row %d: %v", row.ID, artefact)
+//	}) {
+//		...
+//	}
+func SafeSeq[T any](seq iter.Seq[T], h Handler, handle func(artefact any, element T)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var current T
+		inBody := false
+		defer func() {
+			if RecoveryDisabled {
+				return
+			}
+			if artefact := recover(); artefact != nil {
+				h.tackleElement(artefact, inBody, func(resolved any) { handle(resolved, current) })
+			}
+		}()
+		seq(func(element T) bool {
+			current = element
+			inBody = true
+			more := yield(element)
+			inBody = false
+			return more
+		})
+	}
+}
+
+// SafeSeq2 works as SafeSeq for the iterators of pairs, e.g. of maps.All.
+func SafeSeq2[K, V any](seq iter.Seq2[K, V], h Handler, handle func(artefact any, key K, value V)) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var key K
+		var value V
+		inBody := false
+		defer func() {
+			if RecoveryDisabled {
+				return
+			}
+			if artefact := recover(); artefact != nil {
+				h.tackleElement(artefact, inBody, func(resolved any) { handle(resolved, key, value) })
+			}
+		}()
+		seq(func(k K, v V) bool {
+			key, value = k, v
+			inBody = true
+			more := yield(k, v)
+			inBody = false
+			return more
+		})
+	}
+}
+
+// tackleElement tackles the artefact recovered from an iteration, or lets it fall through.
+// A panic of the loop body is raised again once handled.
+func (h Handler) tackleElement(artefact any, inBody bool, handle func(resolved any)) {
+	for _, fn := range h.before {
+		fn()
+	}
+
+	logger := debugLogger.Load()
+	debugRecovered(logger, "SafeSeq", artefact)
+	resolved, matched := h.resolve(artefact, debugTracer(logger, "SafeSeq"))
+	if !matched {
+		debugOutcome(logger, "SafeSeq", "", false)
+		if observingUnhandled() {
+			unhandled(stacked(newEvent(artefact)), "SafeSeq")
+		}
+		panic(artefact)
+	}
+	if logger != nil {
+		debugOutcome(logger, "SafeSeq", funcName(handle), true)
+	}
+	if handlerPanic := runHandle(handle, resolved); handlerPanic != nil {
+		handlerPanicked(artefact, handlerPanic)
+	}
+	reraise(artefact)
+	if inBody {
+		// The range loop panics by itself if the iterator returns after its body panicked.
+		panic(artefact)
+	}
+}
@@ -0,0 +1,91 @@
+package nice
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeSeq(t *testing.T) {
+	errBroken := errors.New("broken")
+	broken := func(yield func(int) bool) {
+		for i := range 5 {
+			if i == 3 {
+				panic(errBroken)
+			}
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	t.Run("iterator panics", func(t *testing.T) {
+		var got []int
+		var handled any
+		last := -1
+		for i := range SafeSeq(broken, Tackle(errBroken), func(artefact any, element int) {
+			handled, last = artefact, element
+		}) {
+			got = append(got, i)
+		}
+
+		assert.Equal(t, []int{0, 1, 2}, got, "the loop ends as if the iterator returned")
+		assert.Equal(t, errBroken, handled)
+		assert.Equal(t, 2, last)
+	})
+
+	t.Run("loop body panics", func(t *testing.T) {
+		errBody := errors.New("body")
+		last := -1
+		assert.PanicsWithValue(t, errBody, func() {
+			for i := range SafeSeq(slices.Values([]int{1, 2, 3}), Tackle(errBody), func(artefact any, element int) {
+				last = element
+			}) {
+				if i == 2 {
+					panic(errBody)
+				}
+			}
+		}, "the body panic is raised again, as range-over-func requires")
+		assert.Equal(t, 2, last, "the handle func sees the element of the body panic")
+	})
+
+	t.Run("not matched", func(t *testing.T) {
+		assert.PanicsWithValue(t, errBroken, func() {
+			for range SafeSeq(broken, Tackle(errors.New("other")), func(any, int) {
+				t.Error("unexpected handling")
+			}) {
+			}
+		})
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var got []int
+		for i := range SafeSeq(broken, Tackle(), func(any, int) {
+			t.Error("unexpected handling")
+		}) {
+			if i == 1 {
+				break
+			}
+			got = append(got, i)
+		}
+		assert.Equal(t, []int{0}, got)
+	})
+}
+
+func TestSafeSeq2(t *testing.T) {
+	errBody := errors.New("body")
+	var key string
+	var value int
+	assert.PanicsWithValue(t, errBody, func() {
+		for range SafeSeq2(maps.All(map[string]int{"a": 1}), Tackle(errBody), func(artefact any, k string, v int) {
+			key, value = k, v
+		}) {
+			panic(errBody)
+		}
+	})
+	assert.Equal(t, "a", key)
+	assert.Equal(t, 1, value)
+}
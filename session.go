@@ -0,0 +1,59 @@
+package nice
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// SessionExitCode is the exit status sent to the client of a session which panicked, as of a Go crash.
+const SessionExitCode = 2
+
+// Session is a session of an SSH server, e.g. gliderlabs/ssh.Session, or of a similar daemon.
+type Session interface {
+	// User returns the name of the authenticated user.
+	User() string
+	// RemoteAddr returns the address of the client.
+	RemoteAddr() net.Addr
+	// Command returns the command requested by the client, empty for a shell.
+	Command() []string
+	// Exit sends the exit status to the client and closes the session.
+	Exit(code int) error
+}
+
+// GuardSession wraps the handler of the sessions of an SSH server, or of a similar daemon,
+// so a panicking session ends with SessionExitCode rather than taking the whole daemon down.
+// The panic is dispatched to the globally registered handlers and reporters,
+// with the user, the remote address and the command of the session recorded in the event metadata
+// as session_user, session_remote_addr and session_command.
+//
+//	ssh.Handle(nice.GuardSession(func(s ssh.Session) {
+//		...
+//	}))
+func GuardSession[S Session](handler func(s S)) func(s S) {
+	return func(s S) {
+		defer func() {
+			if RecoveryDisabled {
+				return
+			}
+			if artefact := recover(); artefact != nil {
+				recoverWith(context.Background(), artefact, sessionMetadata(s), "GuardSession")
+				// The client may be gone already, the panic is what is reported.
+				_ = s.Exit(SessionExitCode)
+			}
+		}()
+		handler(s)
+	}
+}
+
+// sessionMetadata records the session in the event metadata.
+func sessionMetadata(s Session) map[string]string {
+	metadata := map[string]string{"session_user": s.User()}
+	if addr := s.RemoteAddr(); addr != nil {
+		metadata["session_remote_addr"] = addr.String()
+	}
+	if command := s.Command(); len(command) > 0 {
+		metadata["session_command"] = Stringify(strings.Join(command, " "), MaxBytes(256))
+	}
+	return metadata
+}
@@ -0,0 +1,52 @@
+package nice
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockSession records its exit status.
+type mockSession struct {
+	command []string
+	exit    int
+}
+
+func (s *mockSession) User() string { return "deploy" }
+
+func (s *mockSession) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 2222}
+}
+
+func (s *mockSession) Command() []string { return s.command }
+
+func (s *mockSession) Exit(code int) error {
+	s.exit = code
+	return nil
+}
+
+func TestGuardSession(t *testing.T) {
+	events := reported(t)
+
+	handler := GuardSession(func(s *mockSession) {
+		if len(s.command) > 0 {
+			panic("bad session")
+		}
+	})
+	s := &mockSession{command: []string{"deploy", "--all"}}
+	handler(s)
+
+	assert.Equal(t, SessionExitCode, s.exit)
+	if assert.Len(t, events.events, 1) {
+		assert.Equal(t, map[string]string{
+			"session_user":        "deploy",
+			"session_remote_addr": "192.0.2.1:2222",
+			"session_command":     "deploy --all",
+		}, events.events[0].Metadata)
+	}
+
+	shell := &mockSession{exit: -1}
+	handler(shell)
+	assert.Equal(t, -1, shell.exit, "a session which does not panic exits by itself")
+}
@@ -0,0 +1,66 @@
+package nice
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// SortFunc sorts the slice as slices.SortFunc, returning the panic of the comparator as a *PanicError
+// rather than crashing in a frame of the sort. The panic aborts the sort, leaving the slice partially sorted.
+// It is dispatched to the globally registered handlers and reporters,
+// with both compared elements recorded as compare_a and compare_b in the event metadata.
+//
+//	if err := nice.SortFunc(invoices, byDueDate); err != nil {
+//		return err
+//	}
+func SortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) error {
+	return sortGuarded("SortFunc", func(compared func(a, b any)) {
+		slices.SortFunc(s, func(a, b E) int {
+			compared(a, b)
+			return cmp(a, b)
+		})
+	})
+}
+
+// SortStableFunc sorts the slice as slices.SortStableFunc, returning the panic of the comparator as SortFunc.
+func SortStableFunc[S ~[]E, E any](s S, cmp func(a, b E) int) error {
+	return sortGuarded("SortStableFunc", func(compared func(a, b any)) {
+		slices.SortStableFunc(s, func(a, b E) int {
+			compared(a, b)
+			return cmp(a, b)
+		})
+	})
+}
+
+// SortSlice sorts the slice as sort.Slice, returning the panic of less as SortFunc,
+// with the elements at the compared indexes recorded.
+func SortSlice(x any, less func(i, j int) bool) error {
+	return sortGuarded("SortSlice", func(compared func(a, b any)) {
+		v := reflect.ValueOf(x)
+		sort.Slice(x, func(i, j int) bool {
+			compared(v.Index(i).Interface(), v.Index(j).Interface())
+			return less(i, j)
+		})
+	})
+}
+
+// sortGuarded runs the sort, recovering the panic of its comparator with the last compared elements.
+func sortGuarded(recovery string, sort func(compared func(a, b any))) (err error) {
+	var a, b any
+	defer func() {
+		if RecoveryDisabled {
+			return
+		}
+		if artefact := recover(); artefact != nil {
+			event := recoverWith(context.Background(), artefact, map[string]string{
+				"compare_a": Stringify(a, MaxBytes(256)),
+				"compare_b": Stringify(b, MaxBytes(256)),
+			}, recovery)
+			err = &PanicError{Value: artefact, Stack: event.Stack}
+		}
+	}()
+	sort(func(x, y any) { a, b = x, y })
+	return nil
+}
@@ -0,0 +1,51 @@
+package nice
+
+import (
+	"cmp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type invoice struct {
+	ID  int
+	Due *string
+}
+
+func TestSortFunc(t *testing.T) {
+	events := reported(t)
+	due := func(s string) *string { return &s }
+	byDue := func(a, b invoice) int { return strings.Compare(*a.Due, *b.Due) }
+
+	invoices := []invoice{{1, due("2024-03")}, {2, due("2024-01")}}
+	assert.NoError(t, SortFunc(invoices, byDue))
+	assert.Equal(t, 2, invoices[0].ID)
+	assert.NoError(t, SortStableFunc(invoices, func(a, b invoice) int { return cmp.Compare(a.ID, b.ID) }))
+	assert.Equal(t, 1, invoices[0].ID)
+
+	invoices = append(invoices, invoice{3, nil})
+	var panicErr *PanicError
+	assert.ErrorAs(t, SortFunc(invoices, byDue), &panicErr)
+	assert.ElementsMatch(t, []int{1, 2, 3}, []int{invoices[0].ID, invoices[1].ID, invoices[2].ID}, "no element is lost")
+	if assert.Len(t, events.events, 1) {
+		metadata := events.events[0].Metadata
+		assert.Contains(t, metadata["compare_a"]+metadata["compare_b"], "{3 <nil>}", "the elements are captured")
+	}
+}
+
+func TestSortSlice(t *testing.T) {
+	events := reported(t)
+	ids := []int{3, 1, 2}
+
+	assert.NoError(t, SortSlice(ids, func(i, j int) bool { return ids[i] < ids[j] }))
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	err := SortSlice(ids, func(i, j int) bool { return ids[i] < 1/(ids[j]-1) })
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	if assert.Len(t, events.events, 1) {
+		metadata := events.events[0].Metadata
+		assert.Contains(t, []string{metadata["compare_a"], metadata["compare_b"]}, "1")
+	}
+}
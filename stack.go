@@ -0,0 +1,46 @@
+package nice
+
+import "sync/atomic"
+
+// recoveryFrames is the room for the frames of the recovery point above the panic,
+// which are captured and dropped.
+const recoveryFrames = 16
+
+// StackOption configures the capture of stacks by SetStack.
+type StackOption func(*stackConfig)
+
+type stackConfig struct {
+	depth int
+	skip  int
+}
+
+var stackSettings atomic.Pointer[stackConfig]
+
+func init() {
+	SetStack()
+}
+
+// MaxFrames caps the number of frames captured for an event, 64 by default.
+// A non-positive n captures no stack at all.
+func MaxFrames(n int) StackOption {
+	return func(c *stackConfig) { c.depth = max(n, 0) }
+}
+
+// SkipFrames drops n leading frames from the panic site,
+// e.g. the helpers of a library which panic on behalf of their callers.
+func SkipFrames(n int) StackOption {
+	return func(c *stackConfig) { c.skip = max(n, 0) }
+}
+
+// SetStack configures the capture of stacks for the events,
+// so high-throughput services can bound the cost of crash reports.
+// Options not given are reset to their default.
+//
+//	nice.SetStack(nice.MaxFrames(16), nice.SkipFrames(1))
+func SetStack(opts ...StackOption) {
+	c := &stackConfig{depth: maxStackDepth}
+	for _, opt := range opts {
+		opt(c)
+	}
+	stackSettings.Store(c)
+}
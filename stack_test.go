@@ -0,0 +1,56 @@
+package nice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func stackOf(t *testing.T, opts ...StackOption) []Frame {
+	t.Helper()
+	SetStack(opts...)
+	t.Cleanup(func() { SetStack() })
+
+	cleanRegistry(t)
+	var stack []Frame
+	RegisterEvent(reflect.TypeFor[string](), func(e PanicEvent) { stack = e.Stack }, NeedsStack())
+	func() {
+		defer Guard()
+		panicThrough("stack")
+	}()
+	return stack
+}
+
+//go:noinline
+func panicThrough(message string) {
+	panic(message)
+}
+
+func TestSetStack(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		stack := stackOf(t)
+		if assert.Greater(t, len(stack), 2) {
+			assert.Equal(t, "github.com/antonyho/nice.panicThrough", stack[0].Function)
+		}
+	})
+
+	t.Run("max frames", func(t *testing.T) {
+		stack := stackOf(t, MaxFrames(1))
+		if assert.Len(t, stack, 1) {
+			assert.Equal(t, "github.com/antonyho/nice.panicThrough", stack[0].Function)
+		}
+	})
+
+	t.Run("skip frames", func(t *testing.T) {
+		full := stackOf(t)
+		skipped := stackOf(t, SkipFrames(1))
+		if assert.Len(t, skipped, len(full)-1) {
+			assert.Equal(t, full[1:3], skipped[:2])
+		}
+	})
+
+	t.Run("no frames", func(t *testing.T) {
+		assert.Empty(t, stackOf(t, MaxFrames(0)))
+	})
+}
@@ -0,0 +1,95 @@
+package nice
+
+import "context"
+
+// PipelineStage is a stage of a channel pipeline, as returned by Stage.
+type PipelineStage[In, Out any] struct {
+	fn         func(In) Out
+	deadLetter chan<- In
+	onPanic    []func(event PanicEvent, item In)
+}
+
+// Stage wraps fn as a stage of a channel pipeline which survives the panics of fn.
+// The panic of an item is dispatched to the handlers carried by the context of Run,
+// then to the globally registered handlers and reporters, with the item recorded as "item"
+// in the event metadata. The item is dropped, or sent to the dead-letter channel,
+// and the stage carries on with the next item.
+//
+//	parsed := nice.Stage(parse).
+//		DeadLetter(rejected).
+//		Run(ctx, lines)
+func Stage[In, Out any](fn func(In) Out) *PipelineStage[In, Out] {
+	return &PipelineStage[In, Out]{fn: fn}
+}
+
+// DeadLetter sends the items for which fn panicked to the channel.
+// Sending blocks as sending the output does.
+func (s *PipelineStage[In, Out]) DeadLetter(ch chan<- In) *PipelineStage[In, Out] {
+	s.deadLetter = ch
+	return s
+}
+
+// OnPanic adds the handle func given the event and the item for which fn panicked,
+// which runs after the event is dispatched.
+func (s *PipelineStage[In, Out]) OnPanic(handle func(event PanicEvent, item In)) *PipelineStage[In, Out] {
+	s.onPanic = append(s.onPanic, handle)
+	return s
+}
+
+// Run the stage in a new goroutine, calling fn with every item received from in,
+// until in is closed or the context is done.
+// The returned channel of the results is closed once the stage stops.
+func (s *PipelineStage[In, Out]) Run(ctx context.Context, in <-chan In) <-chan Out {
+	out := make(chan Out)
+	go func() {
+		defer close(out)
+		for {
+			var item In
+			select {
+			case <-ctx.Done():
+				return
+			case received, open := <-in:
+				if !open {
+					return
+				}
+				item = received
+			}
+			result, ok := s.process(ctx, item)
+			if !ok {
+				if s.deadLetter == nil {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case s.deadLetter <- item:
+				}
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- result:
+			}
+		}
+	}()
+	return out
+}
+
+// process the item by fn, tackling its panic.
+func (s *PipelineStage[In, Out]) process(ctx context.Context, item In) (result Out, ok bool) {
+	defer func() {
+		if RecoveryDisabled {
+			return
+		}
+		if artefact := recover(); artefact != nil {
+			event := recoverWith(ctx, artefact, map[string]string{"item": Stringify(item, MaxBytes(256))}, "Stage")
+			for _, handle := range s.onPanic {
+				if handlerPanic := runHandle(func(e PanicEvent) { handle(e, item) }, event); handlerPanic != nil {
+					handlerPanicked(artefact, handlerPanic)
+				}
+			}
+		}
+	}()
+	return s.fn(item), true
+}
@@ -0,0 +1,72 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStage(t *testing.T) {
+	cleanRegistry(t)
+	var handled []PanicEvent
+	RegisterEvent(Type[*strconv.NumError](), func(event PanicEvent) {
+		handled = append(handled, event)
+	})
+
+	parse := func(s string) int {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			panic(err)
+		}
+		return n
+	}
+	in := make(chan string)
+	rejected := make(chan string, 2)
+	var failed []string
+	out := Stage(parse).
+		DeadLetter(rejected).
+		OnPanic(func(event PanicEvent, item string) {
+			assert.True(t, event.Handled)
+			failed = append(failed, item)
+		}).
+		Run(context.Background(), in)
+
+	go func() {
+		defer close(in)
+		for _, s := range []string{"1", "x", "2", "y", "3"} {
+			in <- s
+		}
+	}()
+	var results []int
+	for n := range out {
+		results = append(results, n)
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, results, "the stage keeps running after a panic")
+	assert.Equal(t, []string{"x", "y"}, failed)
+	close(rejected)
+	var dead []string
+	for s := range rejected {
+		dead = append(dead, s)
+	}
+	assert.Equal(t, []string{"x", "y"}, dead)
+	assert.Len(t, handled, 2)
+	assert.Equal(t, "x", handled[0].Metadata["item"])
+	var numErr *strconv.NumError
+	assert.True(t, errors.As(handled[0].Artefact.(error), &numErr))
+}
+
+func TestStageContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := Stage(func(n int) int { return n * 2 }).Run(ctx, in)
+
+	in <- 1
+	assert.Equal(t, 2, <-out)
+	cancel()
+	_, open := <-out
+	assert.False(t, open, "the output is closed once the context is done")
+}
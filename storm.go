@@ -0,0 +1,99 @@
+package nice
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// storms tracks the events per fingerprint within the current window.
+// The threshold is atomic, so dispatches check it without locking, as the mutex is only needed to count the events.
+var storms struct {
+	sync.Mutex
+	threshold atomic.Int64
+	window    time.Duration
+	windows   map[string]*stormWindow
+}
+
+type stormWindow struct {
+	start      time.Time
+	count      int
+	suppressed int
+	// first suppressed event, reported in the summary.
+	first PanicEvent
+}
+
+// SuppressStorms protects handlers and reporters against crash loops in hot paths.
+// Once the same panic is handled more than threshold times within the window,
+// the handlers and reporters are no longer called for it until the window ends.
+// Then the reporters receive one summary event, with the number of suppressed panics
+// recorded as "suppressed" in the metadata.
+// Panics are the same if they have the same Fingerprint.
+// A non-positive threshold disables the suppression, as by default.
+//
+//	nice.SuppressStorms(100, time.Second)
+func SuppressStorms(threshold int, window time.Duration) {
+	storms.Lock()
+	defer storms.Unlock()
+	storms.threshold.Store(int64(threshold))
+	storms.window = window
+	storms.windows = nil
+}
+
+// stormsEnabled tells whether SuppressStorms is enabled.
+func stormsEnabled() bool {
+	return storms.threshold.Load() > 0
+}
+
+// storming counts the handled event of the fingerprint, and reports whether it shall be suppressed.
+// The event shall be stacked, for the summary.
+func storming(event PanicEvent, key string) bool {
+	storms.Lock()
+	defer storms.Unlock()
+	threshold := storms.threshold.Load()
+	if threshold <= 0 {
+		return false
+	}
+
+	now := clockNow()
+	w := storms.windows[key]
+	if w == nil || now.Sub(w.start) >= storms.window {
+		if storms.windows == nil {
+			storms.windows = make(map[string]*stormWindow)
+		}
+		storms.windows[key] = &stormWindow{start: now, count: 1}
+		return false
+	}
+	w.count++
+	if int64(w.count) <= threshold {
+		return false
+	}
+
+	w.suppressed++
+	if w.suppressed == 1 {
+		w.first = event
+		currentClock().AfterFunc(storms.window-now.Sub(w.start), func() { summarize(key, w) })
+	}
+	return true
+}
+
+// summarize reports the suppressed events of the ended window.
+func summarize(key string, w *stormWindow) {
+	storms.Lock()
+	if storms.windows[key] == w {
+		delete(storms.windows, key)
+	}
+	event := w.first
+	suppressed := w.suppressed
+	storms.Unlock()
+
+	event.Time = clockNow()
+	event.Metadata = withMetadata(event.Metadata, "suppressed", strconv.Itoa(suppressed))
+	snapshot := loadRegistry()
+	if event = report(event, snapshot.reporters, snapshot.config); event.HandlerPanic != nil {
+		// Off the panicking goroutine, a reporter's panic is only logged.
+		logError(fmt.Errorf("reporter panicked while reporting the summary: %s", Stringify(event.HandlerPanic)))
+	}
+}
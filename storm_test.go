@@ -0,0 +1,53 @@
+package nice
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuppressStorms(t *testing.T) {
+	cleanRegistry(t)
+	SuppressStorms(2, 50*time.Millisecond)
+	t.Cleanup(func() { SuppressStorms(0, 0) })
+	events := make(chanReporter, 10)
+	AddReporter(events)
+	handled := 0
+	Register(reflect.TypeFor[string](), func(any) { handled++ })
+
+	for range 5 {
+		guarded("storm")
+	}
+	guarded("other")
+
+	assert.Equal(t, 3, handled, "Handlers are not called beyond the threshold.")
+	assert.Len(t, events, 3)
+	for range 3 {
+		<-events
+	}
+
+	select {
+	case summary := <-events:
+		assert.Equal(t, "storm", summary.Artefact)
+		assert.True(t, summary.Handled)
+		assert.Equal(t, "3", summary.Metadata["suppressed"])
+	case <-time.After(time.Second):
+		t.Fatal("No summary event is reported.")
+	}
+
+	guarded("storm")
+	assert.Equal(t, 4, handled, "A new window begins after the summary.")
+}
+
+func TestSuppressStormsDisabled(t *testing.T) {
+	cleanRegistry(t)
+	handled := 0
+	Register(reflect.TypeFor[string](), func(any) { handled++ })
+
+	for range 5 {
+		guarded("storm")
+	}
+	assert.Equal(t, 5, handled)
+}
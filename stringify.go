@@ -0,0 +1,189 @@
+package nice
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultStringifyDepth is the depth of nested values formatted by Stringify, unless overridden by MaxDepth.
+	DefaultStringifyDepth = 4
+	// DefaultStringifyBytes is the length of the strings of Stringify, unless overridden by MaxBytes.
+	DefaultStringifyBytes = 4096
+)
+
+// StringifyOption overrides the limits of Stringify.
+type StringifyOption func(*stringifier)
+
+// MaxDepth limits the depth of the nested values formatted. Deeper values are elided as "...".
+func MaxDepth(n int) StringifyOption {
+	return func(s *stringifier) { s.maxDepth = max(n, 1) }
+}
+
+// MaxBytes limits the length of the string, which is truncated with "...".
+func MaxBytes(n int) StringifyOption {
+	return func(s *stringifier) { s.maxBytes = max(n, 1) }
+}
+
+// Stringify formats the value as by fmt.Sprint, but guarded for the artefacts
+// which are expensive to format, or even panic when formatted.
+// Nested values are formatted down to a depth, the string is truncated to a length,
+// and a panic of an Error or String method is rendered in place as fmt does.
+// Built-in handlers format artefacts by it through PanicEvent.Message;
+// it is meant for custom ones too, instead of fmt's %v and %+v.
+//
+//	log.Printf("panicked: %s", nice.Stringify(artefact, nice.MaxBytes(256)))
+func Stringify(v any, opts ...StringifyOption) string {
+	s := stringifier{maxDepth: DefaultStringifyDepth, maxBytes: DefaultStringifyBytes}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	s.value(reflect.ValueOf(v), 0)
+	return s.b.String()
+}
+
+type stringifier struct {
+	b        strings.Builder
+	maxDepth int
+	maxBytes int
+	full     bool
+}
+
+// write the string unless the limit is reached.
+func (s *stringifier) write(str string) {
+	if s.full {
+		return
+	}
+	if room := s.maxBytes - s.b.Len(); len(str) > room {
+		s.b.WriteString(str[:room])
+		s.b.WriteString("...")
+		s.full = true
+		return
+	}
+	s.b.WriteString(str)
+}
+
+func (s *stringifier) value(v reflect.Value, depth int) {
+	if s.full {
+		return
+	}
+	if !v.IsValid() {
+		s.write("<nil>")
+		return
+	}
+	if v.CanInterface() && !isNilPointer(v) {
+		switch formatter := v.Interface().(type) {
+		case error:
+			s.write(guardedCall("Error", formatter.Error))
+			return
+		case fmt.Stringer:
+			s.write(guardedCall("String", formatter.String))
+			return
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		s.write(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s.write(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s.write(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		s.write(strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		s.write(fmt.Sprint(v.Complex()))
+	case reflect.String:
+		s.write(v.String())
+	case reflect.Interface:
+		s.value(v.Elem(), depth)
+	case reflect.Pointer:
+		switch {
+		case v.IsNil():
+			s.write("<nil>")
+		case depth == 0 && isComposite(v.Elem().Kind()):
+			s.write("&")
+			s.value(v.Elem(), depth)
+		default:
+			s.write("0x" + strconv.FormatUint(uint64(v.Pointer()), 16))
+		}
+	case reflect.Struct:
+		s.nested(depth, "{", "}", v.NumField(), func(i int) {
+			s.value(v.Field(i), depth+1)
+		})
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			s.write("[]")
+			return
+		}
+		s.nested(depth, "[", "]", v.Len(), func(i int) {
+			s.value(v.Index(i), depth+1)
+		})
+	case reflect.Map:
+		keys := v.MapKeys()
+		// Sorted for stable output, as by fmt.
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(keyString(a), keyString(b))
+		})
+		s.write("map")
+		s.nested(depth, "[", "]", len(keys), func(i int) {
+			s.value(keys[i], depth+1)
+			s.write(":")
+			s.value(v.MapIndex(keys[i]), depth+1)
+		})
+	default:
+		// Chan, Func and UnsafePointer
+		if v.IsNil() {
+			s.write("<nil>")
+		} else {
+			s.write("0x" + strconv.FormatUint(uint64(v.Pointer()), 16))
+		}
+	}
+}
+
+// nested writes the n elements separated by spaces, or elides them past the depth.
+func (s *stringifier) nested(depth int, open, close string, n int, element func(i int)) {
+	s.write(open)
+	if depth+1 >= s.maxDepth && n > 0 {
+		s.write("...")
+	} else {
+		for i := range n {
+			if s.full {
+				return
+			}
+			if i > 0 {
+				s.write(" ")
+			}
+			element(i)
+		}
+	}
+	s.write(close)
+}
+
+// keyString formats the map key shortly for sorting.
+func keyString(key reflect.Value) string {
+	s := stringifier{maxDepth: 2, maxBytes: 64}
+	s.value(key, 0)
+	return s.b.String()
+}
+
+func isComposite(kind reflect.Kind) bool {
+	return kind == reflect.Struct || kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map
+}
+
+func isNilPointer(v reflect.Value) bool {
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// guardedCall returns the string of the method, or renders its panic as fmt does.
+func guardedCall(method string, fn func() string) (str string) {
+	defer func() {
+		if r := recover(); r != nil {
+			str = fmt.Sprintf("%%!v(PANIC=%s method: %v)", method, r)
+		}
+	}()
+	return fn()
+}
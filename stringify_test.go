@@ -0,0 +1,63 @@
+package nice
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type panickingError struct{}
+
+func (panickingError) Error() string { panic("broken Error") }
+
+type node struct {
+	Name string
+	Next *node
+}
+
+type ledger struct {
+	Owner   string
+	Amounts []int
+	Limits  map[string]int
+	secret  string
+}
+
+func TestStringify(t *testing.T) {
+	list := &node{Name: "a", Next: &node{Name: "b"}}
+	list.Next.Next = list
+
+	for name, tc := range map[string]struct {
+		value    any
+		opts     []StringifyOption
+		expected string
+	}{
+		"Nil":                {nil, nil, "<nil>"},
+		"String":             {"boom", nil, "boom"},
+		"Int":                {42, nil, "42"},
+		"Error":              {errors.New("boom"), nil, "boom"},
+		"Panicking Error":    {panickingError{}, nil, "%!v(PANIC=Error method: broken Error)"},
+		"Nil error pointer":  {(*quotaError)(nil), nil, "<nil>"},
+		"Struct":             {ledger{Owner: "acme", Amounts: []int{1, 2}, Limits: map[string]int{"b": 2, "a": 1}, secret: "x"}, nil, "{acme [1 2] map[a:1 b:2] x}"},
+		"Pointer to struct":  {&ledger{Owner: "acme"}, nil, "&{acme [] map[] }"},
+		"Depth":              {[][]int{{1}, {2}}, []StringifyOption{MaxDepth(1)}, "[...]"},
+		"Truncated":          {strings.Repeat("x", 10), []StringifyOption{MaxBytes(4)}, "xxxx..."},
+		"Truncated elements": {[]string{"aaa", "bbb"}, []StringifyOption{MaxBytes(6)}, "[aaa b..."},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Stringify(tc.value, tc.opts...))
+		})
+	}
+
+	t.Run("Cycle", func(t *testing.T) {
+		// The pointer closing the cycle is printed by its address, which changes across runs.
+		assert.Regexp(t, `^&\{a 0x[0-9a-f]+\}$`, Stringify(list))
+	})
+}
+
+func TestMessageNeverPanics(t *testing.T) {
+	event := PanicEvent{Artefact: panickingError{}}
+	assert.Equal(t, "%!v(PANIC=Error method: broken Error)", event.Message())
+	assert.NotPanics(t, func() { _ = event.String() })
+}
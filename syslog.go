@@ -0,0 +1,54 @@
+//go:build !windows && !plan9
+
+package nice
+
+import (
+	"fmt"
+	"log/syslog"
+	"strings"
+)
+
+// SyslogHandler returns an event handle func writing the event to syslog
+// at the priority of its severity, with the artefact type, the panic frame
+// and the metadata as key=value pairs.
+// Register it with NeedsStack for the panic frame.
+//
+//	w, err := syslog.New(syslog.LOG_DAEMON, "myd")
+//	...
+//	nice.RegisterEvent(nice.Tackle(), nice.SyslogHandler(w), nice.NeedsStack())
+func SyslogHandler(w *syslog.Writer) func(event PanicEvent) {
+	return func(event PanicEvent) {
+		if event.SampledOut() {
+			return
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "panic tackled: %s type=%q handled=%t", event.Message(), event.TypeName(), event.Handled)
+		if len(event.Stack) > 0 {
+			frame := event.Stack[0]
+			fmt.Fprintf(&b, " code_file=%q code_line=%d code_func=%q", frame.File, frame.Line, frame.Function)
+		}
+		for _, k := range sortedKeys(event.Metadata) {
+			fmt.Fprintf(&b, " %s=%q", k, event.Metadata[k])
+		}
+		for _, k := range sortedKeys(event.Tags) {
+			fmt.Fprintf(&b, " tag_%s=%q", k, event.Tags[k])
+		}
+
+		var err error
+		switch syslogPriority(event.Severity) {
+		case 7:
+			err = w.Debug(b.String())
+		case 6:
+			err = w.Info(b.String())
+		case 4:
+			err = w.Warning(b.String())
+		case 2:
+			err = w.Crit(b.String())
+		default:
+			err = w.Err(b.String())
+		}
+		if err != nil {
+			logError(fmt.Errorf("write to syslog: %w", err))
+		}
+	}
+}
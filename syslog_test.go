@@ -0,0 +1,36 @@
+//go:build !windows && !plan9
+
+package nice
+
+import (
+	"log/syslog"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyslogHandler(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("udp unsupported: %v", err)
+	}
+	defer conn.Close()
+	w, err := syslog.Dial("udp", conn.LocalAddr().String(), syslog.LOG_DAEMON, "myd")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer w.Close()
+
+	SyslogHandler(w)(mockEvent())
+
+	buf := make([]byte, 4096)
+	n, _, err := conn.ReadFrom(buf)
+	if assert.NoError(t, err) {
+		message := string(buf[:n])
+		assert.Contains(t, message, "<28>", "Warning of the daemon facility.")
+		assert.Contains(t, message, `panic tackled: mock error type="*errors.errorString"`)
+		assert.Contains(t, message, `code_file="/src/main.go" code_line=9`)
+		assert.Contains(t, message, `request="42"`)
+	}
+}
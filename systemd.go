@@ -0,0 +1,70 @@
+package nice
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultNotifyInterval is the interval of NotifySystemd, if the watchdog of the unit is disabled.
+const DefaultNotifyInterval = 10 * time.Second
+
+// NotifySystemd reports the state of the budget to systemd with sd_notify messages until ctx is done.
+// The STATUS= of the unit tells the panics spent against the budget.
+// While the budget is not exhausted, it keeps the watchdog of the unit alive with WATCHDOG=1,
+// at half of WatchdogSec. Once exhausted, it sends WATCHDOG=trigger,
+// so systemd restarts a unit recovering panics at an unhealthy rate.
+// It returns nil at once if the process is not run by systemd with NotifyAccess.
+//
+//	go nice.NotifySystemd(ctx, budget)
+func NotifySystemd(ctx context.Context, budget *Budget) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+	if strings.HasPrefix(socket, "@") {
+		// Abstract socket namespace of Linux.
+		socket = "\x00" + socket[1:]
+	}
+	interval := DefaultNotifyInterval
+	watchdog := false
+	if usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64); err == nil && usec > 0 {
+		interval = time.Duration(usec) * time.Microsecond / 2
+		watchdog = true
+	}
+
+	conn, err := net.Dial("unixgram", socket)
+	if err != nil {
+		return fmt.Errorf("connect systemd: %w", err)
+	}
+	defer conn.Close()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := conn.Write([]byte(notifyState(budget, watchdog))); err != nil {
+			return fmt.Errorf("notify systemd: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func notifyState(budget *Budget, watchdog bool) string {
+	spent := budget.Spent()
+	if spent > budget.limit {
+		return fmt.Sprintf("STATUS=panic budget exhausted: %d panics in %s, over %d\nWATCHDOG=trigger\n", spent, budget.window, budget.limit)
+	}
+	state := fmt.Sprintf("STATUS=%d of %d panics in %s\n", spent, budget.limit, budget.window)
+	if watchdog {
+		state += "WATCHDOG=1\n"
+	}
+	return state
+}
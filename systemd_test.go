@@ -0,0 +1,50 @@
+package nice
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifySystemd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Skipf("unixgram unsupported: %v", err)
+	}
+	defer conn.Close()
+	t.Setenv("NOTIFY_SOCKET", path)
+	t.Setenv("WATCHDOG_USEC", "20000")
+	budget := NewBudget(1, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- NotifySystemd(ctx, budget) }()
+
+	read := func() string {
+		buf := make([]byte, 1024)
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		assert.NoError(t, err)
+		return string(buf[:n])
+	}
+	assert.Equal(t, "STATUS=0 of 1 panics in 1m0s\nWATCHDOG=1\n", read())
+
+	budget.Report(PanicEvent{})
+	budget.Report(PanicEvent{})
+	assert.Eventually(t, func() bool {
+		return read() == "STATUS=panic budget exhausted: 2 panics in 1m0s, over 1\nWATCHDOG=trigger\n"
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestNotifySystemdWithoutSystemd(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	assert.NoError(t, NotifySystemd(context.Background(), NewBudget(1, time.Minute)))
+}
@@ -69,3 +69,25 @@ func TestTackle(t *testing.T) {
 		assert.Equal(t, expected, h)
 	})
 }
+
+type uncomparableError []string
+
+func (uncomparableError) Error() string { return "uncomparable" }
+
+func TestTackleIndexed(t *testing.T) {
+	targets := make([]any, 0, errorIndexThreshold)
+	errs := make([]error, 0, errorIndexThreshold)
+	for range errorIndexThreshold {
+		err := errors.New("indexed")
+		targets = append(targets, err)
+		errs = append(errs, err)
+	}
+	h := Tackle(targets...)
+
+	assert.NotNil(t, h.errorIndex)
+	assert.True(t, h.matches(errs[errorIndexThreshold-1]))
+	assert.False(t, h.matches(errors.New("indexed")))
+	assert.False(t, h.matches(uncomparableError{"message"}), "Uncomparable artefact never panics in lookup.")
+
+	assert.Nil(t, Tackle(append(targets, uncomparableError{})...).errorIndex, "Uncomparable targets are not indexed.")
+}
@@ -0,0 +1,50 @@
+package nice
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTags(t *testing.T) {
+	t.Run("register option", func(t *testing.T) {
+		cleanRegistry(t)
+		reporter := &mockReporter{}
+		AddReporter(reporter)
+		Register(reflect.TypeFor[string](), func(any) {}, Tags(map[string]string{"team": "payments"}))
+
+		guarded("tagged")
+		assert.Panics(t, func() { guarded(7) })
+
+		assert.Equal(t, map[string]string{"team": "payments"}, reporter.events[0].Tags)
+		assert.Nil(t, reporter.events[1].Tags, "Unhandled events have no tags.")
+	})
+
+	t.Run("tackle target", func(t *testing.T) {
+		cleanRegistry(t)
+		var event PanicEvent
+		h := Tackle(reflect.TypeFor[string](), Tags(map[string]string{"team": "payments", "tier": "1"}))
+		RegisterEvent(h, func(e PanicEvent) { event = e }, Tags(map[string]string{"tier": "2"}))
+
+		assert.True(t, h.matches("tagged"), "Tags are no target.")
+		guarded("tagged")
+
+		assert.Equal(t, map[string]string{"team": "payments", "tier": "2"}, event.Tags, "Tags of the registration override.")
+	})
+}
+
+func TestTagsExported(t *testing.T) {
+	event := mockEvent()
+	event.Tags = map[string]string{"team": "payments", "type": "reserved"}
+
+	sink := &mockSink{}
+	MetricsHandler(sink)(event)
+	assert.Equal(t, map[string]string{"type": "*errors.errorString", "severity": "warning", "team": "payments"}, sink.labels[0])
+
+	encoded, err := json.Marshal(event)
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"tags":{"team":"payments","type":"reserved"}`)
+	assert.Contains(t, event.String(), "\ttag team=payments\n")
+}
@@ -0,0 +1,151 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+	"text/template"
+)
+
+// SafeFuncMap wraps each func of the map, as given to the Funcs of text/template or html/template,
+// so its panic during rendering becomes the error of the template execution,
+// a *PanicError wrapped by "error calling <name>", rather than a failure without context from deep inside the template.
+// The panic is dispatched to the globally registered handlers and reporters,
+// with the name of the func recorded as template_func in the event metadata.
+// Funcs returning a single value are given an error result.
+//
+//	tmpl := template.Must(template.New("page").Funcs(nice.SafeFuncMap(funcs)).Parse(page))
+func SafeFuncMap(fm template.FuncMap) template.FuncMap {
+	safe := make(template.FuncMap, len(fm))
+	for name, fn := range fm {
+		safe[name] = safeFunc(name, fn)
+	}
+	return safe
+}
+
+var errorType = reflect.TypeFor[error]()
+
+// safeFunc wraps the template func. Values which are not valid template funcs are left to Funcs to reject.
+func safeFunc(name string, fn any) any {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		return fn
+	}
+	t := v.Type()
+	if t.NumOut() == 0 || t.NumOut() > 2 || (t.NumOut() == 2 && t.Out(1) != errorType) {
+		return fn
+	}
+	in := make([]reflect.Type, t.NumIn())
+	for i := range in {
+		in[i] = t.In(i)
+	}
+	safe := reflect.FuncOf(in, []reflect.Type{t.Out(0), errorType}, t.IsVariadic())
+	return reflect.MakeFunc(safe, func(args []reflect.Value) (results []reflect.Value) {
+		defer func() {
+			if RecoveryDisabled {
+				return
+			}
+			if artefact := recover(); artefact != nil {
+				event := recoverWith(context.Background(), artefact, map[string]string{"template_func": name}, "SafeFuncMap")
+				var err error = &PanicError{Value: artefact, Stack: event.Stack}
+				results = []reflect.Value{reflect.Zero(t.Out(0)), reflect.ValueOf(&err).Elem()}
+			}
+		}()
+		var out []reflect.Value
+		if t.IsVariadic() {
+			out = v.CallSlice(args)
+		} else {
+			out = v.Call(args)
+		}
+		if len(out) == 1 {
+			out = append(out, reflect.Zero(errorType))
+		}
+		return out
+	}).Interface()
+}
+
+// TemplatePanicError is the error of RenderTemplate for a panic while rendering.
+// It unwraps to the *PanicError.
+type TemplatePanicError struct {
+	// Name of the template.
+	Name string
+	// Position of the execution, as name:line:column, if told by text/template.
+	Position string
+	// Panic recovered.
+	Panic *PanicError
+}
+
+func (e *TemplatePanicError) Error() string {
+	at := e.Name
+	if e.Position != "" {
+		at = e.Position
+	}
+	return fmt.Sprintf("nice: template %s: %s", at, e.Panic.Error())
+}
+
+func (e *TemplatePanicError) Unwrap() error {
+	return e.Panic
+}
+
+// Template is a template executed by RenderTemplate, of text/template or html/template.
+type Template interface {
+	Name() string
+	Execute(w io.Writer, data any) error
+}
+
+// RenderTemplate executes the template with the data into a string, e.g. for emails and reports,
+// returning its panic as a *TemplatePanicError with the template name, rather than crashing.
+// text/template recovers the panics of the method calls on data into execution errors by itself,
+// so they are not panics of RenderTemplate: only runtime errors among them, e.g. a nil dereference in a method,
+// are told apart and returned as a *TemplatePanicError too, with the execution position but without a stack.
+// Other panics of the method calls are indistinguishable from the errors they return.
+// Either is dispatched to the globally registered handlers and reporters,
+// with the template name and position recorded as template and template_position in the event metadata.
+// The errors of the execution are returned as is.
+//
+//	body, err := nice.RenderTemplate(receipt, order)
+func RenderTemplate(t Template, data any) (rendered string, err error) {
+	var b strings.Builder
+	defer func() {
+		if RecoveryDisabled {
+			return
+		}
+		if artefact := recover(); artefact != nil {
+			rendered, err = "", templatePanic(t.Name(), "", stacked(newEvent(artefact)))
+		}
+	}()
+	if err := t.Execute(&b, data); err != nil {
+		var execErr template.ExecError
+		var runtimeErr runtime.Error
+		if errors.As(err, &execErr) && errors.As(err, &runtimeErr) {
+			// The stack of the method call is gone with its recovery, rather than the stack here.
+			return "", templatePanic(t.Name(), execPosition(execErr), PanicEvent{Artefact: runtimeErr, Time: clockNow()})
+		}
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// templatePanic dispatches the event of the panic, and returns its error.
+func templatePanic(name, position string, event PanicEvent) error {
+	event.Metadata = map[string]string{"template": name}
+	if position != "" {
+		event.Metadata["template_position"] = position
+	}
+	event = recovered(context.Background(), event, "RenderTemplate")
+	return &TemplatePanicError{Name: name, Position: position, Panic: &PanicError{Value: event.Artefact, Stack: event.Stack}}
+}
+
+// execPosition returns the position of the execution error, formatted by text/template
+// as "template: name:line:column: executing ...".
+func execPosition(err template.ExecError) string {
+	location, _, found := strings.Cut(strings.TrimPrefix(err.Error(), "template: "), ": ")
+	if !found {
+		return ""
+	}
+	return location
+}
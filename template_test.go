@@ -0,0 +1,89 @@
+package nice
+
+import (
+	"errors"
+	"html/template"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeFuncMap(t *testing.T) {
+	events := reported(t)
+	errPrice := errors.New("no price")
+	funcs := SafeFuncMap(template.FuncMap{
+		"price": func(cents int) string {
+			if cents < 0 {
+				panic(errPrice)
+			}
+			return "$" + strings.Repeat("9", cents)
+		},
+		"join": func(sep string, parts ...string) (string, error) { return strings.Join(parts, sep), nil },
+	})
+	tmpl := template.Must(template.New("page").Funcs(funcs).Parse(`{{join "," "a" "b"}} {{price .}}`))
+
+	var b strings.Builder
+	assert.NoError(t, tmpl.Execute(&b, 2))
+	assert.Equal(t, "a,b $99", b.String())
+
+	err := tmpl.Execute(&b, -1)
+	var panicErr *PanicError
+	if assert.ErrorAs(t, err, &panicErr) {
+		assert.Equal(t, errPrice, panicErr.Value)
+	}
+	assert.ErrorContains(t, err, "error calling price: panic: no price")
+	if assert.Len(t, events.events, 1) {
+		assert.Equal(t, "price", events.events[0].Metadata["template_func"])
+	}
+}
+
+type customer struct{ name string }
+
+type receipt struct{ customer *customer }
+
+func (r receipt) Customer() string { return r.customer.name }
+
+func (r receipt) Total() string { panic("no total") }
+
+// panickingTemplate panics as a broken Template would, beyond the method calls.
+type panickingTemplate struct{}
+
+func (panickingTemplate) Name() string { return "broken" }
+
+func (panickingTemplate) Execute(io.Writer, any) error { panic("escaper broken") }
+
+func TestRenderTemplate(t *testing.T) {
+	events := reported(t)
+	tmpl := template.Must(template.New("receipt").Parse(`Dear {{.Customer}},`))
+
+	rendered, err := RenderTemplate(tmpl, receipt{&customer{"Ada"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Dear Ada,", rendered)
+
+	_, err = RenderTemplate(tmpl, receipt{})
+	var templateErr *TemplatePanicError
+	if assert.ErrorAs(t, err, &templateErr) {
+		assert.Equal(t, "receipt", templateErr.Name)
+		assert.Equal(t, "receipt:1:7", templateErr.Position)
+	}
+	var panicErr *PanicError
+	if assert.ErrorAs(t, err, &panicErr) {
+		assert.Nil(t, panicErr.Stack, "the stack of the method call is gone")
+	}
+	if assert.Len(t, events.events, 1) {
+		assert.Equal(t, map[string]string{"template": "receipt", "template_position": "receipt:1:7"}, events.events[0].Metadata)
+	}
+
+	_, err = RenderTemplate(panickingTemplate{}, nil)
+	assert.EqualError(t, err, "nice: template broken: panic: escaper broken")
+
+	_, err = RenderTemplate(template.Must(template.New("total").Parse(`{{.Total}}`)), receipt{})
+	assert.False(t, errors.As(err, &templateErr), "other panics of the method calls are execution errors")
+	assert.ErrorContains(t, err, "no total")
+
+	_, err = RenderTemplate(template.Must(template.New("missing").Parse(`{{.Missing}}`)), receipt{})
+	assert.False(t, errors.As(err, &templateErr), "the errors of the execution are returned as is")
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package nice
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+)
+
+type tenantKey struct{}
+
+var tenantResolver atomic.Pointer[func(ctx context.Context) string]
+
+// WithTenant returns a copy of ctx for the tenant, e.g. the customer ID,
+// for GuardContext, Dispatch and nicehttp.Middleware to route by ForTenant.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// SetTenantResolver sets how the tenant is found in the context of a recovery point,
+// for the platforms keeping it under a context key of their own instead of by WithTenant.
+// Passing nil restores WithTenant.
+//
+//	nice.SetTenantResolver(func(ctx context.Context) string { return auth.FromContext(ctx).CustomerID })
+func SetTenantResolver(resolve func(ctx context.Context) string) {
+	if resolve == nil {
+		tenantResolver.Store(nil)
+		return
+	}
+	tenantResolver.Store(&resolve)
+}
+
+// TenantFromContext returns the tenant of the context, by the resolver set by SetTenantResolver, or by WithTenant.
+func TenantFromContext(ctx context.Context) string {
+	if resolve := tenantResolver.Load(); resolve != nil {
+		return (*resolve)(ctx)
+	}
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// ForTenant restricts the registration to the panics recovered for the tenants,
+// so multi-tenant platforms apply different panic policies per tenant from one registration API.
+// The handlers of the tenant are consulted ahead of the handlers for every tenant,
+// and the events recovered for a tenant are tagged with it as "tenant".
+//
+//	nice.RegisterEvent(reflect.TypeFor[error](), pageOnCall, nice.ForTenant("acme", "globex"))
+//	nice.RegisterEvent(reflect.TypeFor[error](), logOnly)
+func ForTenant(tenants ...string) RegisterOption {
+	return func(o *registerOptions) {
+		o.tenants = append(slices.Clip(o.tenants), tenants...)
+	}
+}
+
+// forTenant orders the registrations of the tenant ahead of those for every tenant, keeping their order,
+// and drops those of the other tenants.
+func forTenant(tenant string, registrations []registration) []registration {
+	ordered := make([]registration, 0, len(registrations))
+	for _, r := range registrations {
+		if len(r.tenants) > 0 && slices.Contains(r.tenants, tenant) {
+			ordered = append(ordered, r)
+		}
+	}
+	for _, r := range registrations {
+		if len(r.tenants) == 0 {
+			ordered = append(ordered, r)
+		}
+	}
+	return slices.Clip(ordered)
+}
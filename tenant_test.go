@@ -0,0 +1,56 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForTenant(t *testing.T) {
+	cleanRegistry(t)
+	var handled []string
+	handle := func(name string) func(any) { return func(any) { handled = append(handled, name) } }
+	Register(reflect.TypeFor[error](), handle("everyone"))
+	var tags map[string]string
+	RegisterChained(reflect.TypeFor[error](), func(event PanicEvent) HandleResult {
+		handled = append(handled, "enterprise")
+		tags = event.Tags
+		return Stop
+	}, ForTenant("acme", "globex"))
+	Register(reflect.TypeFor[error](), handle("initech"), ForTenant("initech"))
+	reporter := &mockReporter{}
+	AddReporter(reporter)
+	guardedIn := func(ctx context.Context) {
+		defer GuardContext(ctx)
+		panic(errors.New("boom"))
+	}
+
+	guardedIn(WithTenant(context.Background(), "acme"))
+	guardedIn(WithTenant(context.Background(), "umbrella"))
+	guardedIn(context.Background())
+
+	assert.Equal(t, []string{"enterprise", "everyone", "everyone"}, handled,
+		"the handlers of the tenant come first, and those of other tenants are skipped")
+	assert.Equal(t, map[string]string{"tenant": "acme"}, tags, "the handlers see the tenant tag")
+	assert.Equal(t, map[string]string{"tenant": "acme"}, reporter.events[0].Tags)
+	assert.Empty(t, reporter.events[2].Tags)
+	assert.Equal(t, []string{"acme", "globex"}, Registered()[1].Tenants)
+}
+
+func TestSetTenantResolver(t *testing.T) {
+	type customerKey struct{}
+	SetTenantResolver(func(ctx context.Context) string {
+		customer, _ := ctx.Value(customerKey{}).(string)
+		return customer
+	})
+	t.Cleanup(func() { SetTenantResolver(nil) })
+
+	assert.Equal(t, "acme", TenantFromContext(context.WithValue(context.Background(), customerKey{}, "acme")))
+	assert.Empty(t, TenantFromContext(WithTenant(context.Background(), "ignored")))
+
+	SetTenantResolver(nil)
+	assert.Equal(t, "acme", TenantFromContext(WithTenant(context.Background(), "acme")))
+}
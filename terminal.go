@@ -0,0 +1,45 @@
+package nice
+
+import (
+	"os"
+)
+
+// Escape sequences undoing the common modes a TUI program switches on:
+// reset attributes, disable mouse tracking and bracketed paste,
+// leave the alternate screen and show the cursor.
+const terminalReset = "\x1b[0m" +
+	"\x1b[?1000l\x1b[?1002l\x1b[?1003l\x1b[?1006l" +
+	"\x1b[?2004l" +
+	"\x1b[?1049l" +
+	"\x1b[?25h"
+
+// RestoreTerminal snapshots the current state of the terminal behind `in`
+// and returns a Handler which restores it on panic,
+// before the artefact is matched and handled.
+// It shall be called before the TUI program switches the terminal into raw mode,
+// which is naturally the case when it is evaluated in a defer statement.
+// The reset sequences for cursor, alternate screen and mouse modes
+// are written to `out` if it is a terminal. Passing nil skips them.
+//
+//	defer nice.Tackle().RestoreTerminal(os.Stdin, os.Stdout).With(handle)
+//	tea.NewProgram(model).Run()
+//
+// The terminal is restored even if the panic falls through,
+// so a crashing TUI program does not leave the shell broken.
+func (h Handler) RestoreTerminal(in, out *os.File) Handler {
+	state, err := getTermState(in)
+	h.before = append(h.before[:len(h.before):len(h.before)], func() {
+		if err == nil {
+			_ = setTermState(in, state)
+		}
+		if out != nil && isTerminal(out) {
+			_, _ = out.WriteString(terminalReset)
+		}
+	})
+	return h
+}
+
+func isTerminal(f *os.File) bool {
+	_, err := getTermState(f)
+	return err == nil
+}
@@ -0,0 +1,32 @@
+package nice
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+)
+
+type termState = syscall.Termios
+
+func getTermState(f *os.File) (*termState, error) {
+	if f == nil {
+		return nil, syscall.EBADF
+	}
+	state := new(termState)
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), ioctlGetTermios, uintptr(unsafe.Pointer(state))); errno != 0 {
+		return nil, errno
+	}
+	return state, nil
+}
+
+func setTermState(f *os.File, state *termState) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), ioctlSetTermios, uintptr(unsafe.Pointer(state))); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package nice_test
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+	"testing"
+	"unsafe"
+)
+
+// openTerminal opens a pseudo terminal, returning its controlling side and the terminal.
+func openTerminal(t *testing.T) (pty, tty *os.File) {
+	pty, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("no pseudo terminal: %v", err)
+	}
+	t.Cleanup(func() { pty.Close() })
+	var unlock int32
+	if err := ioctl(pty, syscall.TIOCSPTLCK, unsafe.Pointer(&unlock)); err != nil {
+		t.Skipf("no pseudo terminal: %v", err)
+	}
+	var n uint32
+	if err := ioctl(pty, syscall.TIOCGPTN, unsafe.Pointer(&n)); err != nil {
+		t.Skipf("no pseudo terminal: %v", err)
+	}
+	tty, err = os.OpenFile("/dev/pts/"+strconv.Itoa(int(n)), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("no pseudo terminal: %v", err)
+	}
+	t.Cleanup(func() { tty.Close() })
+	return pty, tty
+}
+
+// echoing tells whether the terminal echoes its input, as a TUI program in raw mode does not.
+func echoing(t *testing.T, tty *os.File) bool {
+	var state syscall.Termios
+	if err := ioctl(tty, syscall.TCGETS, unsafe.Pointer(&state)); err != nil {
+		t.Fatal(err)
+	}
+	return state.Lflag&syscall.ECHO != 0
+}
+
+// disableEcho switches the terminal echo off, as a TUI program entering raw mode does.
+func disableEcho(t *testing.T, tty *os.File) {
+	var state syscall.Termios
+	if err := ioctl(tty, syscall.TCGETS, unsafe.Pointer(&state)); err != nil {
+		t.Fatal(err)
+	}
+	state.Lflag &^= syscall.ECHO
+	if err := ioctl(tty, syscall.TCSETS, unsafe.Pointer(&state)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func ioctl(f *os.File, request uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), request, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package nice
+
+import (
+	"errors"
+	"os"
+)
+
+type termState struct{}
+
+var errNoTermios = errors.New("terminal state is not supported on this platform")
+
+func getTermState(*os.File) (*termState, error) {
+	return nil, errNoTermios
+}
+
+func setTermState(*os.File, *termState) error {
+	return errNoTermios
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+
+package nice_test
+
+import (
+	"os"
+	"testing"
+)
+
+func openTerminal(t *testing.T) (pty, tty *os.File) {
+	t.Skip("pseudo terminals are opened on Linux only")
+	return nil, nil
+}
+
+func echoing(*testing.T, *os.File) bool { return false }
+
+func disableEcho(*testing.T, *os.File) {}
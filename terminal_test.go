@@ -0,0 +1,62 @@
+package nice_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/antonyho/nice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreTerminal(t *testing.T) {
+	t.Run("handle after restoring", func(t *testing.T) {
+		in, out, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer in.Close()
+
+		mockHandler := &mockHandler{Executed: false}
+		defer func() {
+			out.Close()
+			written, _ := io.ReadAll(in)
+			assert.Empty(t, written, "Reset sequences are not written to non-terminal output.")
+		}()
+		defer assertExecuted(t, mockHandler)
+
+		defer nice.Tackle().RestoreTerminal(in, out).With(mockHandler.Handle)
+
+		panicFunc := func() {
+			panic(errors.New("tui crashed"))
+		}
+		panicFunc()
+	})
+
+	t.Run("restore on fallthrough", func(t *testing.T) {
+		pty, tty := openTerminal(t)
+		mockHandler := &mockHandler{Executed: false}
+		defer assertNotExecuted(t, mockHandler)
+		defer func() {
+			if artefact := recover(); artefact == nil {
+				t.Error("Unhandled panic did not fallthrough.")
+			}
+			assert.True(t, echoing(t, tty), "The terminal state is restored.")
+			_ = pty.SetReadDeadline(time.Now().Add(time.Second))
+			reset := make([]byte, 64)
+			n, _ := pty.Read(reset)
+			assert.Contains(t, string(reset[:n]), "\x1b[?1049l", "Reset sequences are written to the terminal.")
+		}()
+
+		defer nice.Tackle(reflect.TypeFor[string]()).RestoreTerminal(tty, tty).With(mockHandler.Handle)
+
+		panicFunc := func() {
+			disableEcho(t, tty)
+			panic(7)
+		}
+		panicFunc()
+	})
+}
@@ -0,0 +1,41 @@
+package nice
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TerminateHandler returns an event handle func which terminates the process gracefully,
+// for the fatal class of targets, instead of either crashing at once or limping along.
+// It drains the in-flight work with shutdown, given a context which expires after timeout,
+// flushes the reporters, then exits with DefaultHandledExitCode.
+// The termination runs in its own goroutine and only once,
+// so shutdown can wait for the work of the recovering goroutine too, e.g. with http.Server.Shutdown.
+//
+//	nice.RegisterEvent(ErrCorruptState, nice.TerminateHandler(server.Shutdown, 30*time.Second))
+func TerminateHandler(shutdown func(ctx context.Context) error, timeout time.Duration) func(event PanicEvent) {
+	var once sync.Once
+	return func(event PanicEvent) {
+		once.Do(func() {
+			go terminate(event, shutdown, timeout)
+		})
+	}
+}
+
+func terminate(event PanicEvent, shutdown func(ctx context.Context) error, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	if err := shutdown(ctx); err != nil {
+		logError(fmt.Errorf("shutdown on %s: %w", event.Type(), err))
+	}
+	cancel()
+
+	flushCtx, cancel := context.WithTimeout(context.Background(), DefaultFlushTimeout)
+	if err := Flush(flushCtx); err != nil {
+		logError(fmt.Errorf("flush reporters: %w", err))
+	}
+	cancel()
+
+	osExit(DefaultHandledExitCode)
+}
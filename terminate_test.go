@@ -0,0 +1,43 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerminateHandler(t *testing.T) {
+	cleanRegistry(t)
+	exited := make(chan int, 2)
+	exit := osExit
+	osExit = func(code int) { exited <- code }
+	t.Cleanup(func() { osExit = exit })
+	reporter := &mockReporter{}
+	AddReporter(reporter)
+
+	shutdowns := 0
+	var deadline time.Time
+	RegisterEvent(reflect.TypeFor[string](), TerminateHandler(func(ctx context.Context) error {
+		shutdowns++
+		deadline, _ = ctx.Deadline()
+		return errors.New("still draining")
+	}, time.Minute))
+
+	guarded("fatal")
+	guarded("fatal")
+
+	select {
+	case code := <-exited:
+		assert.Equal(t, DefaultHandledExitCode, code)
+	case <-time.After(time.Second):
+		t.Fatal("The process does not exit.")
+	}
+	assert.Equal(t, 1, shutdowns, "Termination runs once.")
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+	assert.True(t, reporter.flushed)
+	assert.Empty(t, exited)
+}
@@ -0,0 +1,75 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+)
+
+// TraceExtractor returns the IDs of the trace and span carried by the context, if any,
+// e.g. of the OpenTelemetry span:
+//
+//	nice.SetTraceExtractor(func(ctx context.Context) (string, string, bool) {
+//		sc := trace.SpanContextFromContext(ctx)
+//		return sc.TraceID().String(), sc.SpanID().String(), sc.IsValid()
+//	})
+type TraceExtractor func(ctx context.Context) (traceID, spanID string, ok bool)
+
+var traceExtractor atomic.Pointer[TraceExtractor]
+
+// SetTraceExtractor sets how the trace and span are found in the context of the recovery point,
+// to be recorded as trace_id and span_id in the event metadata,
+// so panics can be joined with distributed traces.
+// A nil extractor restores the default, which reads the W3C trace context of WithTraceParent.
+func SetTraceExtractor(extract TraceExtractor) {
+	if extract == nil {
+		traceExtractor.Store(nil)
+		return
+	}
+	traceExtractor.Store(&extract)
+}
+
+type traceParentKey struct{}
+
+type traceParent struct {
+	traceID string
+	spanID  string
+}
+
+// ErrTraceParent is returned by WithTraceParent for a malformed traceparent.
+var ErrTraceParent = errors.New("malformed traceparent")
+
+// WithTraceParent returns a copy of the context carrying the W3C trace context
+// of the traceparent header, e.g. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+// The middleware of nicehttp calls it for the requests with the header.
+func WithTraceParent(ctx context.Context, header string) (context.Context, error) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		!isTraceID(parts[1], 32) || !isTraceID(parts[2], 16) || len(parts[3]) != 2 {
+		return ctx, ErrTraceParent
+	}
+	return context.WithValue(ctx, traceParentKey{}, traceParent{traceID: parts[1], spanID: parts[2]}), nil
+}
+
+// isTraceID tells whether id is n lowercase hex digits, not all zero.
+func isTraceID(id string, n int) bool {
+	if len(id) != n || strings.Trim(id, "0") == "" {
+		return false
+	}
+	for _, r := range id {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// traceMetadata returns the trace and span IDs found in the context.
+func traceMetadata(ctx context.Context) (traceID, spanID string, ok bool) {
+	if extract := traceExtractor.Load(); extract != nil {
+		return (*extract)(ctx)
+	}
+	parent, ok := ctx.Value(traceParentKey{}).(traceParent)
+	return parent.traceID, parent.spanID, ok
+}
@@ -0,0 +1,40 @@
+package nice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTraceParent(t *testing.T) {
+	cleanRegistry(t)
+	ctx, err := WithTraceParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.NoError(t, err)
+
+	event := Dispatch(ctx, "traced")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", event.Metadata["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", event.Metadata["span_id"])
+
+	for _, header := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	} {
+		_, err := WithTraceParent(context.Background(), header)
+		assert.ErrorIs(t, err, ErrTraceParent, header)
+	}
+	assert.NotContains(t, Dispatch(context.Background(), "untraced").Metadata, "trace_id")
+}
+
+func TestSetTraceExtractor(t *testing.T) {
+	cleanRegistry(t)
+	SetTraceExtractor(func(context.Context) (string, string, bool) { return "trace", "span", true })
+	t.Cleanup(func() { SetTraceExtractor(nil) })
+
+	event := Dispatch(context.Background(), "traced")
+	assert.Equal(t, "trace", event.Metadata["trace_id"])
+	assert.Equal(t, "span", event.Metadata["span_id"])
+}
@@ -0,0 +1,71 @@
+package nice
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// ArtefactType is the description of an artefact type registered by Describe.
+type ArtefactType struct {
+	// Name of the type for humans, e.g. "payment-decline".
+	Name string
+	// Team owning the type, tagged as "team" into the events of its artefacts.
+	Team string
+}
+
+// TypeOption adds to the description of an artefact type.
+type TypeOption func(*ArtefactType)
+
+// Team sets the team owning the artefact type.
+func Team(team string) TypeOption {
+	return func(t *ArtefactType) { t.Team = team }
+}
+
+// describedTypes maps the reflect types to their descriptions, copied on write.
+var describedTypes struct {
+	sync.Mutex
+	types atomic.Pointer[map[reflect.Type]ArtefactType]
+}
+
+// Describe registers the human-readable name of the artefact type T, and who owns it.
+// The name is used instead of the type name by the logs, the metrics labels and the reports,
+// as PanicEvent.TypeName, and the team is tagged into the events of its artefacts.
+// Only the artefacts of exactly T are described, so describe *T and T apart.
+//
+//	nice.Describe[*payments.DeclineError]("payment-decline", nice.Team("payments"))
+func Describe[T any](name string, opts ...TypeOption) {
+	described := ArtefactType{Name: name}
+	for _, opt := range opts {
+		opt(&described)
+	}
+
+	describedTypes.Lock()
+	defer describedTypes.Unlock()
+	types := make(map[reflect.Type]ArtefactType)
+	if current := describedTypes.types.Load(); current != nil {
+		for t, d := range *current {
+			types[t] = d
+		}
+	}
+	types[reflect.TypeFor[T]()] = described
+	describedTypes.types.Store(&types)
+}
+
+// DescribedType returns the description of the type of the artefact registered by Describe.
+func DescribedType(artefact any) (ArtefactType, bool) {
+	types := describedTypes.types.Load()
+	if types == nil || artefact == nil {
+		return ArtefactType{}, false
+	}
+	described, found := (*types)[reflect.TypeOf(artefact)]
+	return described, found
+}
+
+// describedTags of the artefact are the tags of its described type.
+func describedTags(artefact any) map[string]string {
+	if described, found := DescribedType(artefact); found && described.Team != "" {
+		return map[string]string{"team": described.Team}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package nice
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type declineError struct{}
+
+func (*declineError) Error() string { return "declined" }
+
+func TestDescribe(t *testing.T) {
+	saved := describedTypes.types.Load()
+	t.Cleanup(func() { describedTypes.types.Store(saved) })
+	Describe[*declineError]("payment-decline", Team("payments"))
+
+	described, found := DescribedType(&declineError{})
+	assert.True(t, found)
+	assert.Equal(t, ArtefactType{Name: "payment-decline", Team: "payments"}, described)
+	_, found = DescribedType(declineError{})
+	assert.False(t, found, "only the exact type is described")
+
+	cleanRegistry(t)
+	var output bytes.Buffer
+	sink := &mockSink{}
+	reporter := &mockReporter{}
+	AddReporter(reporter)
+	RegisterChained(reflect.TypeFor[error](), func(event PanicEvent) HandleResult {
+		MetricsHandler(sink)(event)
+		return Continue
+	}, Tags(map[string]string{"tier": "1"}))
+	RegisterEvent(reflect.TypeFor[error](), LogHandler(slog.New(slog.NewJSONHandler(&output, nil))))
+
+	guarded(&declineError{})
+
+	assert.Contains(t, output.String(), `"type":"payment-decline"`)
+	assert.Contains(t, output.String(), `"tags":{"team":"payments"}`)
+	assert.Equal(t, map[string]string{"type": "payment-decline", "severity": "default", "team": "payments", "tier": "1"}, sink.labels[0])
+	event := reporter.events[0]
+	assert.Equal(t, "*nice.declineError", event.Type())
+	assert.Equal(t, "payment-decline", event.TypeName())
+	assert.Contains(t, event.String(), "panic: declined (payment-decline) [recovered]")
+
+	encoded, err := json.Marshal(event)
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"type":"*nice.declineError","name":"payment-decline"`)
+	var decoded PanicEvent
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, "payment-decline", decoded.TypeName())
+	assert.Equal(t, "*nice.declineError", decoded.Type())
+}
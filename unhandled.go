@@ -0,0 +1,63 @@
+package nice
+
+import (
+	"sync/atomic"
+)
+
+// observers of the package, set globally.
+type observers struct {
+	metrics     MetricsSink
+	onUnhandled func(event PanicEvent)
+}
+
+var observed atomic.Pointer[observers]
+
+func init() {
+	observed.Store(&observers{})
+}
+
+// updateObservers replaces the observers with the updated copy.
+func updateObservers(update func(o *observers)) {
+	for {
+		current := observed.Load()
+		updated := *current
+		update(&updated)
+		if observed.CompareAndSwap(current, &updated) {
+			return
+		}
+	}
+}
+
+// SetMetrics sets the sink of the metrics counted by the recovery points.
+// Passing nil stops counting.
+func SetMetrics(sink MetricsSink) {
+	updateObservers(func(o *observers) { o.metrics = sink })
+}
+
+// OnUnhandled sets the callback given the event of every panic
+// which falls through because no target matches, right before it is raised again.
+// Passing nil removes the callback.
+func OnUnhandled(callback func(event PanicEvent)) {
+	updateObservers(func(o *observers) { o.onUnhandled = callback })
+}
+
+// observingUnhandled tells whether unhandled needs an event.
+func observingUnhandled() bool {
+	o := observed.Load()
+	return o.metrics != nil || o.onUnhandled != nil
+}
+
+// unhandled counts the fallthrough of a panic as MetricUnhandled
+// and calls the OnUnhandled callback.
+func unhandled(event PanicEvent, recovery string) {
+	o := observed.Load()
+	if o.metrics != nil {
+		o.metrics.Count(MetricUnhandled, map[string]string{
+			"type":     event.TypeName(),
+			"recovery": recovery,
+		})
+	}
+	if o.onUnhandled != nil {
+		o.onUnhandled(event)
+	}
+}
@@ -0,0 +1,73 @@
+package nice
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func observeUnhandled(t *testing.T) (*mockSink, *[]PanicEvent) {
+	t.Helper()
+	sink := &mockSink{}
+	var events []PanicEvent
+	SetMetrics(sink)
+	OnUnhandled(func(event PanicEvent) { events = append(events, event) })
+	t.Cleanup(func() {
+		SetMetrics(nil)
+		OnUnhandled(nil)
+	})
+	return sink, &events
+}
+
+func TestOnUnhandled(t *testing.T) {
+	t.Run("With", func(t *testing.T) {
+		sink, events := observeUnhandled(t)
+
+		assert.Panics(t, func() {
+			defer Tackle(reflect.TypeFor[string]()).With(func(any) {})
+			panic(7)
+		})
+
+		assert.Equal(t, []string{MetricUnhandled}, sink.counts)
+		assert.Equal(t, map[string]string{"type": "int", "recovery": "With"}, sink.labels[0])
+		if assert.Len(t, *events, 1) {
+			assert.Equal(t, 7, (*events)[0].Artefact)
+			assert.NotEmpty(t, (*events)[0].Stack)
+		}
+	})
+
+	t.Run("Guard", func(t *testing.T) {
+		cleanRegistry(t)
+		sink, events := observeUnhandled(t)
+
+		assert.Panics(t, func() { guarded(7) })
+
+		assert.Equal(t, map[string]string{"type": "int", "recovery": "Guard"}, sink.labels[0])
+		assert.Len(t, *events, 1)
+	})
+
+	t.Run("Main", func(t *testing.T) {
+		cleanRegistry(t)
+		mockExit(t)
+		sink, _ := observeUnhandled(t)
+
+		Main(func(context.Context) int { panic(7) }, ReportTo(io.Discard))
+
+		assert.Equal(t, map[string]string{"type": "int", "recovery": "Main"}, sink.labels[0])
+	})
+
+	t.Run("handled", func(t *testing.T) {
+		sink, events := observeUnhandled(t)
+
+		func() {
+			defer Tackle().With(func(any) {})
+			panic(assert.AnError)
+		}()
+
+		assert.Empty(t, sink.counts)
+		assert.Empty(t, *events)
+	})
+}
@@ -0,0 +1,19 @@
+package nice
+
+// maxUnwrapDepth bounds the chain of PanicWrapper followed when matching, against cycles.
+const maxUnwrapDepth = 32
+
+// PanicWrapper is an optional interface of custom panic payloads wrapping another one,
+// e.g. added by middleware to carry the request along the original artefact.
+// When the wrapper itself does not match, the unwrapped artefacts are matched along the chain,
+// and the handle func receives the matched one, so wrappers do not defeat inner handlers.
+//
+//	type requestPanic struct {
+//		Request *http.Request
+//		Value   any
+//	}
+//
+//	func (p requestPanic) UnwrapPanic() any { return p.Value }
+type PanicWrapper interface {
+	UnwrapPanic() any
+}
@@ -0,0 +1,61 @@
+package nice
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type requestPanic struct {
+	request string
+	value   any
+}
+
+func (p requestPanic) UnwrapPanic() any { return p.value }
+
+// loopPanic unwraps to itself.
+type loopPanic struct{}
+
+func (p loopPanic) UnwrapPanic() any { return p }
+
+func TestPanicWrapper(t *testing.T) {
+	errNotFound := errors.New("not found")
+	wrapped := requestPanic{request: "GET /", value: requestPanic{request: "inner", value: errNotFound}}
+
+	t.Run("Unwrapped", func(t *testing.T) {
+		var received any
+		func() {
+			defer Tackle(errNotFound).With(func(artefact any) { received = artefact })
+			panic(wrapped)
+		}()
+		assert.Equal(t, errNotFound, received)
+	})
+
+	t.Run("Wrapper matches first", func(t *testing.T) {
+		var received any
+		func() {
+			defer Tackle(reflect.TypeFor[requestPanic](), errNotFound).With(func(artefact any) { received = artefact })
+			panic(wrapped)
+		}()
+		assert.Equal(t, wrapped, received)
+	})
+
+	t.Run("Registered", func(t *testing.T) {
+		cleanRegistry(t)
+		var received any
+		Register(errNotFound, func(artefact any) { received = artefact })
+
+		event := Dispatch(context.Background(), wrapped)
+
+		assert.True(t, event.Handled)
+		assert.Equal(t, wrapped, event.Artefact)
+		assert.Equal(t, errNotFound, received)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		assert.False(t, Tackle(errNotFound).matches(loopPanic{}))
+	})
+}
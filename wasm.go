@@ -0,0 +1,38 @@
+package nice
+
+import "context"
+
+// WASMModule is the guest module calling a host function, e.g. wazero's api.Module.
+type WASMModule interface {
+	// Name of the module instance.
+	Name() string
+}
+
+// GuardHostFunc wraps the host function of the name exposed to WASM guests,
+// as wazero's api.GoModuleFunc, so a panic triggered by a guest call is recovered
+// rather than unwinding through the runtime, and translated into the error visible to the guest:
+// the first result on the stack, if the function has any, is set to errno.
+// The panic is dispatched to the handlers carried by the context, then to the globally registered handlers
+// and reporters, with the module and function names recorded as wasm_module and wasm_function in the event metadata.
+//
+//	builder.NewFunctionBuilder().
+//		WithGoModuleFunction(api.GoModuleFunc(nice.GuardHostFunc("fetch", errnoFault, fetch)),
+//			[]api.ValueType{api.ValueTypeI32}, []api.ValueType{api.ValueTypeI32}).
+//		Export("fetch")
+func GuardHostFunc[M WASMModule](name string, errno uint64, fn func(ctx context.Context, mod M, stack []uint64)) func(ctx context.Context, mod M, stack []uint64) {
+	return func(ctx context.Context, mod M, stack []uint64) {
+		defer func() {
+			if RecoveryDisabled {
+				return
+			}
+			if artefact := recover(); artefact != nil {
+				metadata := map[string]string{"wasm_module": mod.Name(), "wasm_function": name}
+				recoverWith(ctx, artefact, metadata, "GuardHostFunc")
+				if len(stack) > 0 {
+					stack[0] = errno
+				}
+			}
+		}()
+		fn(ctx, mod, stack)
+	}
+}
@@ -0,0 +1,35 @@
+package nice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockModule string
+
+func (m mockModule) Name() string { return string(m) }
+
+func TestGuardHostFunc(t *testing.T) {
+	events := reported(t)
+	const errnoFault = 21
+	fetch := GuardHostFunc("fetch", errnoFault, func(_ context.Context, _ mockModule, stack []uint64) {
+		if stack[0] == 0 {
+			panic("null pointer from the guest")
+		}
+		stack[0] = 0
+	})
+
+	stack := []uint64{8}
+	fetch(context.Background(), "guest", stack)
+	assert.Equal(t, []uint64{0}, stack)
+	assert.Empty(t, events.events)
+
+	stack = []uint64{0}
+	fetch(context.Background(), "guest", stack)
+	assert.Equal(t, []uint64{errnoFault}, stack, "the guest sees the errno")
+	if assert.Len(t, events.events, 1) {
+		assert.Equal(t, map[string]string{"wasm_module": "guest", "wasm_function": "fetch"}, events.events[0].Metadata)
+	}
+}